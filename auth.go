@@ -0,0 +1,304 @@
+package widevine
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnauthorized is returned by an Authorizer when the request carries no
+// valid credentials. The license proxy answers 401 for it.
+var ErrUnauthorized = errors.New("widevine: unauthorized")
+
+// AuthorizationRequest describes the license a player asks for. Challenge
+// is the decoded license challenge, or nil when it could not be decoded.
+type AuthorizationRequest struct {
+	ContentID  string
+	TrackTypes []TrackType
+	Challenge  *LicenseChallenge
+}
+
+// Authorization is the decision of an Authorizer. The non-zero fields of
+// LicenseOptions override the license options of the handler for this
+// request.
+type Authorization struct {
+	Allowed        bool
+	LicenseOptions *LicenseOptions
+}
+
+// Authorizer decides whether a license may be requested. A denied
+// authorization is answered with 403, an error wrapping ErrUnauthorized with
+// 401 and any other error with 500.
+type Authorizer interface {
+	Authorize(r *http.Request, req AuthorizationRequest) (Authorization, error)
+}
+
+// AuthorizerFunc adapts a function to the Authorizer interface.
+type AuthorizerFunc func(r *http.Request, req AuthorizationRequest) (Authorization, error)
+
+// Authorize calls f(r, req).
+func (f AuthorizerFunc) Authorize(r *http.Request, req AuthorizationRequest) (Authorization, error) {
+	return f(r, req)
+}
+
+// merge returns o with the non-zero fields of override applied.
+func (o LicenseOptions) merge(override *LicenseOptions) LicenseOptions {
+	if override == nil {
+		return o
+	}
+	if override.Policy != "" {
+		o.Policy = override.Policy
+	}
+	if override.PolicyOverrides != nil {
+		o.PolicyOverrides = override.PolicyOverrides
+	}
+	if override.ContentKeySpecs != nil {
+		o.ContentKeySpecs = override.ContentKeySpecs
+	}
+	if override.AllowedTrackTypes != "" {
+		o.AllowedTrackTypes = override.AllowedTrackTypes
+	}
+	return o
+}
+
+// JWTClaims are the claims of a verified JWT.
+type JWTClaims map[string]interface{}
+
+// JWTAuthorizer authorizes requests carrying an "Authorization: Bearer"
+// JWT signed with HS256 using HMACKey or RS256 using RSAKey.
+//
+// The exp and nbf claims are checked with Leeway, and iss and aud when
+// Issuer or Audience are set. Decide makes the decision from the verified
+// claims; when nil, the token must carry a "content_id" claim, or a
+// "content_ids" list, matching the requested content ID, and an optional
+// "allowed_track_types" claim sets the allowed track types of the license.
+type JWTAuthorizer struct {
+	HMACKey  []byte
+	RSAKey   *rsa.PublicKey
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	Decide   func(claims JWTClaims, req AuthorizationRequest) (Authorization, error)
+
+	now func() time.Time
+}
+
+// Authorize verifies the bearer token of r.
+func (a *JWTAuthorizer) Authorize(r *http.Request, req AuthorizationRequest) (Authorization, error) {
+	if a.HMACKey != nil && len(a.HMACKey) == 0 {
+		return Authorization{}, errors.New("widevine: JWTAuthorizer HMACKey is empty")
+	}
+	if len(a.HMACKey) == 0 && a.RSAKey == nil {
+		return Authorization{}, errors.New("widevine: JWTAuthorizer requires an HMACKey or RSAKey")
+	}
+
+	auth := r.Header.Get("Authorization")
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		return Authorization{}, fmt.Errorf("%w: missing bearer token", ErrUnauthorized)
+	}
+
+	claims, err := a.verify(strings.TrimSpace(auth[7:]))
+	if err != nil {
+		return Authorization{}, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if a.Decide != nil {
+		return a.Decide(claims, req)
+	}
+	return defaultJWTDecision(claims, req)
+}
+
+func (a *JWTAuthorizer) verify(token string) (JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed signature")
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	switch {
+	case header.Alg == "HS256" && a.HMACKey != nil:
+		mac := hmac.New(sha256.New, a.HMACKey)
+		mac.Write(signed)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return nil, errors.New("invalid signature")
+		}
+	case header.Alg == "RS256" && a.RSAKey != nil:
+		h := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(a.RSAKey, crypto.SHA256, h[:], sig); err != nil {
+			return nil, errors.New("invalid signature")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+
+	var claims JWTClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return claims, a.validateClaims(claims)
+}
+
+func (a *JWTAuthorizer) validateClaims(claims JWTClaims) error {
+	now := time.Now()
+	if a.now != nil {
+		now = a.now()
+	}
+
+	exp, err := numericClaim(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if exp != nil && now.After(time.Unix(int64(*exp), 0).Add(a.Leeway)) {
+		return errors.New("token expired")
+	}
+	nbf, err := numericClaim(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if nbf != nil && now.Add(a.Leeway).Before(time.Unix(int64(*nbf), 0)) {
+		return errors.New("token not yet valid")
+	}
+	if a.Issuer != "" && claims["iss"] != a.Issuer {
+		return errors.New("unexpected issuer")
+	}
+	if a.Audience != "" && !claimContains(claims["aud"], a.Audience) {
+		return errors.New("unexpected audience")
+	}
+	return nil
+}
+
+// numericClaim returns the claim name, or nil when it is absent. A claim of
+// another type than a JSON number is an error.
+func numericClaim(claims JWTClaims, name string) (*float64, error) {
+	v, ok := claims[name]
+	if !ok {
+		return nil, nil
+	}
+	n, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s claim", name)
+	}
+	return &n, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.New("malformed token")
+	}
+	return nil
+}
+
+// claimContains reports whether a string or string list claim contains v.
+func claimContains(claim interface{}, v string) bool {
+	switch c := claim.(type) {
+	case string:
+		return c == v
+	case []interface{}:
+		for _, s := range c {
+			if s == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func defaultJWTDecision(claims JWTClaims, req AuthorizationRequest) (Authorization, error) {
+	if !claimContains(claims["content_id"], req.ContentID) && !claimContains(claims["content_ids"], req.ContentID) {
+		return Authorization{Allowed: false}, nil
+	}
+
+	auth := Authorization{Allowed: true}
+	if v, ok := claims["allowed_track_types"].(string); ok {
+		allowed := AllowedTrackTypes(v)
+		if !allowed.Valid() {
+			return Authorization{}, fmt.Errorf("%w: invalid allowed_track_types claim %q", ErrUnauthorized, v)
+		}
+		auth.LicenseOptions = &LicenseOptions{AllowedTrackTypes: allowed}
+	}
+	return auth, nil
+}
+
+// SignedURLAuthorizer authorizes requests carrying a token and expiry in
+// the query string, as issued by SignURLToken with the same Key.
+// TokenParam and ExpiresParam default to "token" and "expires".
+type SignedURLAuthorizer struct {
+	Key          []byte
+	TokenParam   string
+	ExpiresParam string
+
+	now func() time.Time
+}
+
+// SignURLToken returns a token allowing licenses for contentID until
+// expires. The token and the expiry as Unix seconds are sent as query
+// parameters of the license request.
+func SignURLToken(key []byte, contentID string, expires time.Time) string {
+	return base64.RawURLEncoding.EncodeToString(urlTokenMAC(key, contentID, expires.Unix()))
+}
+
+func urlTokenMAC(key []byte, contentID string, expires int64) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(contentID + "\n" + strconv.FormatInt(expires, 10)))
+	return mac.Sum(nil)
+}
+
+// Authorize verifies the query-string token of r.
+func (a *SignedURLAuthorizer) Authorize(r *http.Request, req AuthorizationRequest) (Authorization, error) {
+	if len(a.Key) == 0 {
+		return Authorization{}, errors.New("widevine: SignedURLAuthorizer requires a Key")
+	}
+
+	tokenParam, expiresParam := a.TokenParam, a.ExpiresParam
+	if tokenParam == "" {
+		tokenParam = "token"
+	}
+	if expiresParam == "" {
+		expiresParam = "expires"
+	}
+
+	q := r.URL.Query()
+	token, err := base64.RawURLEncoding.DecodeString(q.Get(tokenParam))
+	if err != nil || len(token) == 0 {
+		return Authorization{}, fmt.Errorf("%w: missing or malformed token", ErrUnauthorized)
+	}
+	expires, err := strconv.ParseInt(q.Get(expiresParam), 10, 64)
+	if err != nil {
+		return Authorization{}, fmt.Errorf("%w: missing or malformed expiry", ErrUnauthorized)
+	}
+
+	now := time.Now()
+	if a.now != nil {
+		now = a.now()
+	}
+	if now.Unix() > expires {
+		return Authorization{}, fmt.Errorf("%w: token expired", ErrUnauthorized)
+	}
+	if !hmac.Equal(token, urlTokenMAC(a.Key, req.ContentID, expires)) {
+		return Authorization{Allowed: false}, nil
+	}
+	return Authorization{Allowed: true}, nil
+}
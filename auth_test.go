@@ -0,0 +1,203 @@
+package widevine
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testHMACKey = []byte("secret")
+
+func signJWT(t *testing.T, alg string, claims map[string]interface{}, key interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		h := sha256.Sum256([]byte(signed))
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, h[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func bearerRequest(token string) *http.Request {
+	r := httptest.NewRequest("POST", "/proxy", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWTAuthorizerHS256(t *testing.T) {
+	a := &JWTAuthorizer{HMACKey: testHMACKey, Issuer: "auth.example.com"}
+	req := AuthorizationRequest{ContentID: "testing"}
+	exp := float64(time.Now().Add(time.Hour).Unix())
+
+	token := signJWT(t, "HS256", map[string]interface{}{
+		"iss":                 "auth.example.com",
+		"exp":                 exp,
+		"content_ids":         []string{"other", "testing"},
+		"allowed_track_types": "SD_ONLY",
+	}, testHMACKey)
+	auth, err := a.Authorize(bearerRequest(token), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !auth.Allowed || auth.LicenseOptions == nil || auth.LicenseOptions.AllowedTrackTypes != SDOnly {
+		t.Errorf("unexpected authorization %+v", auth)
+	}
+
+	// Another title.
+	token = signJWT(t, "HS256", map[string]interface{}{"iss": "auth.example.com", "content_id": "other"}, testHMACKey)
+	if auth, err := a.Authorize(bearerRequest(token), req); err != nil || auth.Allowed {
+		t.Errorf("expected denial, got %+v %v", auth, err)
+	}
+}
+
+func TestJWTAuthorizerRS256(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &JWTAuthorizer{RSAKey: &priv.PublicKey}
+
+	token := signJWT(t, "RS256", map[string]interface{}{"content_id": "testing"}, priv)
+	auth, err := a.Authorize(bearerRequest(token), AuthorizationRequest{ContentID: "testing"})
+	if err != nil || !auth.Allowed {
+		t.Errorf("expected authorization, got %+v %v", auth, err)
+	}
+
+	// HS256 tokens must not verify against an RSA-only authorizer.
+	token = signJWT(t, "HS256", map[string]interface{}{"content_id": "testing"}, testHMACKey)
+	if _, err := a.Authorize(bearerRequest(token), AuthorizationRequest{ContentID: "testing"}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestJWTAuthorizerInvalid(t *testing.T) {
+	now := time.Now()
+	a := &JWTAuthorizer{HMACKey: testHMACKey, Audience: "license", now: func() time.Time { return now }}
+	req := AuthorizationRequest{ContentID: "testing"}
+
+	tests := map[string]*http.Request{
+		"missing":   httptest.NewRequest("POST", "/proxy", nil),
+		"malformed": bearerRequest("abc.def"),
+		"signature": bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": "license"}, []byte("other"))),
+		"none":      bearerRequest(signJWT(t, "none", map[string]interface{}{"aud": "license"}, nil)),
+		"expired":   bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": "license", "exp": now.Add(-time.Minute).Unix()}, testHMACKey)),
+		"nbf":       bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": "license", "nbf": now.Add(time.Minute).Unix()}, testHMACKey)),
+		"audience":  bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": []string{"other"}}, testHMACKey)),
+		"exp type":  bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": "license", "exp": "1700000000"}, testHMACKey)),
+		"nbf type":  bearerRequest(signJWT(t, "HS256", map[string]interface{}{"aud": "license", "nbf": nil}, testHMACKey)),
+	}
+	for name, r := range tests {
+		if _, err := a.Authorize(r, req); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestSignedURLAuthorizer(t *testing.T) {
+	a := &SignedURLAuthorizer{Key: testHMACKey}
+	expires := time.Now().Add(time.Hour)
+	token := SignURLToken(testHMACKey, "testing", expires)
+
+	target := fmt.Sprintf("/proxy?token=%s&expires=%d", url.QueryEscape(token), expires.Unix())
+	r := httptest.NewRequest("POST", target, nil)
+
+	if auth, err := a.Authorize(r, AuthorizationRequest{ContentID: "testing"}); err != nil || !auth.Allowed {
+		t.Errorf("expected authorization, got %+v %v", auth, err)
+	}
+	if auth, err := a.Authorize(r, AuthorizationRequest{ContentID: "other"}); err != nil || auth.Allowed {
+		t.Errorf("expected denial, got %+v %v", auth, err)
+	}
+
+	expired := time.Now().Add(-time.Hour)
+	target = fmt.Sprintf("/proxy?token=%s&expires=%d", SignURLToken(testHMACKey, "testing", expired), expired.Unix())
+	if _, err := a.Authorize(httptest.NewRequest("POST", target, nil), AuthorizationRequest{ContentID: "testing"}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthorizerEmptyKey(t *testing.T) {
+	req := AuthorizationRequest{ContentID: "testing"}
+
+	expires := time.Now().Add(time.Hour)
+	target := fmt.Sprintf("/proxy?token=%s&expires=%d", SignURLToken(nil, "testing", expires), expires.Unix())
+	auth, err := (&SignedURLAuthorizer{}).Authorize(httptest.NewRequest("POST", target, nil), req)
+	if err == nil || errors.Is(err, ErrUnauthorized) || auth.Allowed {
+		t.Errorf("SignedURLAuthorizer: expected configuration error, got %+v %v", auth, err)
+	}
+
+	token := signJWT(t, "HS256", map[string]interface{}{"content_id": "testing"}, []byte{})
+	for name, a := range map[string]*JWTAuthorizer{"empty": {HMACKey: []byte{}}, "none": {}} {
+		auth, err = a.Authorize(bearerRequest(token), req)
+		if err == nil || errors.Is(err, ErrUnauthorized) || auth.Allowed {
+			t.Errorf("JWTAuthorizer %s: expected configuration error, got %+v %v", name, auth, err)
+		}
+	}
+}
+
+func TestHandlerAuthorizer(t *testing.T) {
+	var allowed string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var envelope map[string]string
+		json.NewDecoder(r.Body).Decode(&envelope)
+		dec, _ := base64.StdEncoding.DecodeString(envelope["request"])
+		var req map[string]interface{}
+		json.Unmarshal(dec, &req)
+		allowed, _ = req["allowed_track_types"].(string)
+		fmt.Fprintf(w, `{"status": "OK", "license": ""}`)
+	}))
+	defer ts.Close()
+
+	var got AuthorizationRequest
+	h := testHandler(t, ts.URL)
+	h.opts.Authorizer = AuthorizerFunc(func(r *http.Request, req AuthorizationRequest) (Authorization, error) {
+		got = req
+		switch r.Header.Get("X-User") {
+		case "":
+			return Authorization{}, ErrUnauthorized
+		case "basic":
+			return Authorization{Allowed: true, LicenseOptions: &LicenseOptions{AllowedTrackTypes: SDOnly}}, nil
+		}
+		return Authorization{Allowed: false}, nil
+	})
+
+	tests := map[string]int{"": http.StatusUnauthorized, "basic": http.StatusOK, "blocked": http.StatusForbidden}
+	for user, code := range tests {
+		r := httptest.NewRequest("POST", "/proxy?content_id=testing", strings.NewReader("challenge"))
+		r.Header.Set("X-User", user)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Errorf("%q: got %d, want %d", user, w.Code, code)
+		}
+	}
+
+	if allowed != "SD_ONLY" {
+		t.Errorf("expected SD_ONLY override, got %q", allowed)
+	}
+	if got.ContentID != "testing" || len(got.TrackTypes) != 4 {
+		t.Errorf("unexpected authorization request %+v", got)
+	}
+}
@@ -0,0 +1,138 @@
+package widevine
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// Default lifetime of a service certificate fetched by the license proxy.
+const defaultServiceCertificateTTL = 24 * time.Hour
+
+// serviceCertificateRequest is the challenge a CDM sends to request the
+// service certificate, a SignedMessage of type SERVICE_CERTIFICATE_REQUEST.
+var serviceCertificateRequest = []byte{0x08, 0x04}
+
+// IsServiceCertificateRequest reports whether the challenge b requests the
+// service certificate. Players in privacy mode send it before the license
+// challenge.
+func IsServiceCertificateRequest(b []byte) bool {
+	msg := &proto.SignedMessage{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return false
+	}
+	return msg.GetType() == proto.SignedMessage_SERVICE_CERTIFICATE_REQUEST
+}
+
+// ServiceCertificateMessage returns the response to a service certificate
+// request for cert. cert is either a signed service certificate, as issued
+// by Widevine, or a SignedMessage of type SERVICE_CERTIFICATE, which is
+// returned unchanged.
+func ServiceCertificateMessage(cert []byte) ([]byte, error) {
+	if len(cert) == 0 {
+		return nil, errors.New("widevine: empty service certificate")
+	}
+	msg := &proto.SignedMessage{}
+	if cert[0] == 0x08 && protobuf.Unmarshal(cert, msg) == nil {
+		if msg.GetType() != proto.SignedMessage_SERVICE_CERTIFICATE {
+			return nil, fmt.Errorf("widevine: unexpected message type %s for service certificate", msg.GetType())
+		}
+		return cert, nil
+	}
+	return protobuf.Marshal(&proto.SignedMessage{
+		Type: proto.SignedMessage_SERVICE_CERTIFICATE.Enum(),
+		Msg:  cert,
+	})
+}
+
+// RequestServiceCertificate fetches the service certificate of the provider
+// from Widevine Cloud. The result is the response to a service certificate
+// request and can be sent to players as is.
+func (wp *Widevine) RequestServiceCertificate(ctx context.Context) ([]byte, error) {
+	msg, err := wp.buildCKMessage(ctx, map[string]interface{}{
+		"payload":  base64.StdEncoding.EncodeToString(serviceCertificateRequest),
+		"provider": wp.Provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+	url, err := wp.licenseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// The request has no side effects and can be retried.
+	resp := GetLicenseResponse{}
+	if err := wp.httpClient().postIdempotentContext(ctx, url, &resp, msg, nil); err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	cert, err := base64.StdEncoding.DecodeString(resp.License)
+	if err != nil {
+		return nil, &DecodeError{Body: []byte(resp.License), Err: err}
+	}
+	return ServiceCertificateMessage(cert)
+}
+
+// certificateCache holds the service certificate fetched by a Handler.
+type certificateCache struct {
+	mu      sync.Mutex
+	cert    []byte
+	expires time.Time
+	call    *certificateCall
+}
+
+// certificateCall is a fetch shared by all requests waiting for it.
+type certificateCall struct {
+	done chan struct{}
+	cert []byte
+	err  error
+}
+
+// get returns the cached certificate. When it is missing or expired, a
+// single fetch bounded by timeout is started for all waiting requests. The
+// fetch does not depend on ctx, which only bounds the wait of this request.
+func (c *certificateCache) get(ctx context.Context, ttl, timeout time.Duration, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	if c.cert != nil && time.Now().Before(c.expires) {
+		cert := c.cert
+		c.mu.Unlock()
+		return cert, nil
+	}
+	call := c.call
+	if call == nil {
+		call = &certificateCall{done: make(chan struct{})}
+		c.call = call
+		go c.fetch(call, ttl, timeout, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.cert, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *certificateCache) fetch(call *certificateCall, ttl, timeout time.Duration, fetch func(context.Context) ([]byte, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	call.cert, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	if call.err == nil {
+		c.cert, c.expires = call.cert, time.Now().Add(ttl)
+	}
+	c.call = nil
+	c.mu.Unlock()
+	close(call.done)
+}
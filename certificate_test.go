@@ -0,0 +1,158 @@
+package widevine
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+var testServiceCertificate = []byte("signed-service-certificate")
+
+func TestIsServiceCertificateRequest(t *testing.T) {
+	tests := []struct {
+		b    []byte
+		want bool
+	}{
+		{[]byte{0x08, 0x04}, true},
+		{[]byte{0x08, 0x01, 0x12, 0x00}, false},
+		{[]byte("challenge"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsServiceCertificateRequest(tt.b); got != tt.want {
+			t.Errorf("IsServiceCertificateRequest(%x) = %v, want %v", tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestServiceCertificateMessage(t *testing.T) {
+	b, err := ServiceCertificateMessage(testServiceCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &proto.SignedMessage{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.GetType() != proto.SignedMessage_SERVICE_CERTIFICATE || !bytes.Equal(msg.GetMsg(), testServiceCertificate) {
+		t.Errorf("got %v", msg)
+	}
+
+	// A SignedMessage is returned unchanged.
+	again, err := ServiceCertificateMessage(b)
+	if err != nil || !bytes.Equal(again, b) {
+		t.Errorf("got %x, %v, want %x", again, err, b)
+	}
+
+	if _, err := ServiceCertificateMessage([]byte{0x08, 0x04}); err == nil {
+		t.Error("expected error for a service certificate request")
+	}
+}
+
+func TestHandlerServiceCertificate(t *testing.T) {
+	h := testHandler(t, "http://127.0.0.1:0")
+	h.opts.ServiceCertificate = testServiceCertificate
+
+	r := httptest.NewRequest("POST", "/proxy", bytes.NewReader(serviceCertificateRequest))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	want, _ := ServiceCertificateMessage(testServiceCertificate)
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), want) {
+		t.Errorf("got %d %x, want %x", w.Code, w.Body.Bytes(), want)
+	}
+}
+
+func TestHandlerServiceCertificateFetch(t *testing.T) {
+	want, _ := ServiceCertificateMessage(testServiceCertificate)
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var envelope map[string]string
+		json.NewDecoder(r.Body).Decode(&envelope)
+		dec, _ := base64.StdEncoding.DecodeString(envelope["request"])
+
+		var req map[string]string
+		json.Unmarshal(dec, &req)
+		if req["payload"] != base64.StdEncoding.EncodeToString(serviceCertificateRequest) {
+			t.Errorf("unexpected payload %s", req["payload"])
+		}
+		fmt.Fprintf(w, `{"status": "OK", "license": %q}`, base64.StdEncoding.EncodeToString(want))
+	}))
+	defer ts.Close()
+
+	h := testHandler(t, ts.URL)
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("POST", "/proxy", bytes.NewReader(serviceCertificateRequest))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), want) {
+			t.Errorf("got %d %x, want %x", w.Code, w.Body.Bytes(), want)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1 cached fetch", requests)
+	}
+}
+
+func TestCertificateCacheSharedFetch(t *testing.T) {
+	var c certificateCache
+	var calls int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-release:
+			return testServiceCertificate, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// A player giving up does not cancel the fetch of the others.
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := c.get(ctx, time.Hour, time.Minute, fetch)
+		canceled <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-canceled; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if cert, err := c.get(context.Background(), time.Hour, time.Minute, fetch); err != nil || !bytes.Equal(cert, testServiceCertificate) {
+				t.Errorf("got %q, %v", cert, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if cert, err := c.get(context.Background(), time.Hour, time.Minute, fetch); err != nil || !bytes.Equal(cert, testServiceCertificate) {
+		t.Errorf("got %q, %v", cert, err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("got %d fetches, want 1", n)
+	}
+}
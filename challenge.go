@@ -0,0 +1,152 @@
+package widevine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// RequestType is the type of a license request.
+type RequestType string
+
+// License request types.
+const (
+	RequestNew     RequestType = "NEW"
+	RequestRenewal RequestType = "RENEWAL"
+	RequestRelease RequestType = "RELEASE"
+)
+
+// LicenseChallenge is a license challenge sent by a CDM, decoded locally.
+//
+// ContentID and KeyIDs are read from the Widevine PSSH data of NEW requests.
+// SessionID is only set for RENEWAL and RELEASE requests, which identify the
+// license they refer to instead of carrying a PSSH. Client is nil in privacy
+// mode, when the client identification is encrypted with the service
+// certificate.
+type LicenseChallenge struct {
+	Type        RequestType
+	RequestTime time.Time
+	RequestID   []byte
+	SessionID   []byte
+	ContentID   []byte
+	KeyIDs      [][]byte
+	PSSHData    []*proto.WidevinePsshData
+	Client      *ClientHints
+	PrivacyMode bool
+
+	// Request is the decoded LicenseRequest message.
+	Request *proto.LicenseRequest
+}
+
+// ClientHints are the client details reported in a license challenge.
+// MaxHDCP is empty when the client does not report HDCP support.
+type ClientHints struct {
+	Info                   map[string]string
+	MaxHDCP                HDCP
+	OEMCryptoAPIVersion    uint32
+	AnalogOutput           string
+	CanDisableAnalogOutput bool
+	ResourceRatingTier     uint32
+}
+
+// ParseLicenseChallenge decodes the raw license challenge b, a SignedMessage
+// carrying a LicenseRequest. The signature is not verified.
+func ParseLicenseChallenge(b []byte) (*LicenseChallenge, error) {
+	msg := &proto.SignedMessage{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return nil, fmt.Errorf("widevine: malformed license challenge: %v", err)
+	}
+	if msg.GetType() != proto.SignedMessage_LICENSE_REQUEST {
+		return nil, fmt.Errorf("widevine: unexpected message type %s in license challenge", msg.GetType())
+	}
+	req := &proto.LicenseRequest{}
+	if err := protobuf.Unmarshal(msg.GetMsg(), req); err != nil {
+		return nil, fmt.Errorf("widevine: malformed license request: %v", err)
+	}
+
+	c := &LicenseChallenge{
+		Type:        RequestType(req.GetType().String()),
+		PrivacyMode: req.GetEncryptedClientId() != nil,
+		Request:     req,
+	}
+	if req.Type == nil {
+		c.Type = RequestNew
+	}
+	if t := req.GetRequestTime(); t > 0 {
+		c.RequestTime = time.Unix(t, 0)
+	}
+	if req.ClientId != nil {
+		c.Client = clientHints(req.ClientId)
+	}
+	if err := c.readContentID(req.GetContentId()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *LicenseChallenge) readContentID(id *proto.LicenseRequest_ContentIdentification) error {
+	var psshData [][]byte
+	switch {
+	case id.GetWidevinePsshData() != nil:
+		c.RequestID = id.GetWidevinePsshData().GetRequestId()
+		psshData = id.GetWidevinePsshData().GetPssh()
+	case id.GetInitData() != nil:
+		c.RequestID = id.GetInitData().GetRequestId()
+		if id.GetInitData().GetInitDataType() != proto.LicenseRequest_ContentIdentification_InitData_CENC {
+			return nil
+		}
+		boxes, err := ParsePSSH(id.GetInitData().GetInitData())
+		if err != nil {
+			return err
+		}
+		for _, box := range boxes {
+			if box.IsWidevine() {
+				psshData = append(psshData, box.Data)
+			}
+		}
+	case id.GetExistingLicense() != nil:
+		licenseID := id.GetExistingLicense().GetLicenseId()
+		c.RequestID = licenseID.GetRequestId()
+		c.SessionID = licenseID.GetSessionId()
+		return nil
+	case id.GetWebmKeyId() != nil:
+		c.RequestID = id.GetWebmKeyId().GetRequestId()
+		c.KeyIDs = [][]byte{id.GetWebmKeyId().GetHeader()}
+		return nil
+	default:
+		return errors.New("widevine: license challenge has no content identification")
+	}
+
+	for _, b := range psshData {
+		data := &proto.WidevinePsshData{}
+		if err := protobuf.Unmarshal(b, data); err != nil {
+			return fmt.Errorf("widevine: malformed PSSH data: %v", err)
+		}
+		c.PSSHData = append(c.PSSHData, data)
+		if c.ContentID == nil {
+			c.ContentID = data.GetContentId()
+		}
+		c.KeyIDs = append(c.KeyIDs, data.GetKeyIds()...)
+	}
+	return nil
+}
+
+func clientHints(id *proto.ClientIdentification) *ClientHints {
+	h := &ClientHints{Info: make(map[string]string)}
+	for _, nv := range id.GetClientInfo() {
+		h.Info[nv.GetName()] = nv.GetValue()
+	}
+	if caps := id.GetClientCapabilities(); caps != nil {
+		if caps.MaxHdcpVersion != nil {
+			h.MaxHDCP = HDCP(caps.GetMaxHdcpVersion().String())
+		}
+		h.OEMCryptoAPIVersion = caps.GetOemCryptoApiVersion()
+		h.AnalogOutput = caps.GetAnalogOutputCapabilities().String()
+		h.CanDisableAnalogOutput = caps.GetCanDisableAnalogOutput()
+		h.ResourceRatingTier = caps.GetResourceRatingTier()
+	}
+	return h
+}
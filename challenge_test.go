@@ -0,0 +1,158 @@
+package widevine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+func signedChallenge(t *testing.T, req *proto.LicenseRequest) []byte {
+	msg, err := protobuf.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := protobuf.Marshal(&proto.SignedMessage{
+		Type:      proto.SignedMessage_LICENSE_REQUEST.Enum(),
+		Msg:       msg,
+		Signature: []byte("signature"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseLicenseChallengeInitData(t *testing.T) {
+	p, err := BuildPSSH(PSSHOptions{
+		KeyIDs:    [][]byte{testKeyID},
+		Provider:  "widevine_test",
+		ContentID: []byte("testing"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	box, err := p.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := signedChallenge(t, &proto.LicenseRequest{
+		Type:        proto.LicenseRequest_NEW.Enum(),
+		RequestTime: protobuf.Int64(1500000000),
+		ClientId: &proto.ClientIdentification{
+			ClientInfo: []*proto.ClientIdentification_NameValue{
+				{Name: protobuf.String("company_name"), Value: protobuf.String("Google")},
+			},
+			ClientCapabilities: &proto.ClientIdentification_ClientCapabilities{
+				MaxHdcpVersion:      proto.ClientIdentification_ClientCapabilities_HDCP_V2_2.Enum(),
+				OemCryptoApiVersion: protobuf.Uint32(15),
+			},
+		},
+		ContentId: &proto.LicenseRequest_ContentIdentification{
+			InitData: &proto.LicenseRequest_ContentIdentification_InitData{
+				InitData:  box,
+				RequestId: []byte("request"),
+			},
+		},
+	})
+
+	c, err := ParseLicenseChallenge(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != RequestNew {
+		t.Errorf("got type %s, want NEW", c.Type)
+	}
+	if c.RequestTime.Unix() != 1500000000 {
+		t.Errorf("got request time %v", c.RequestTime)
+	}
+	if string(c.RequestID) != "request" || c.SessionID != nil {
+		t.Errorf("got request ID %q, session ID %q", c.RequestID, c.SessionID)
+	}
+	if string(c.ContentID) != "testing" {
+		t.Errorf("got content ID %q, want testing", c.ContentID)
+	}
+	if len(c.KeyIDs) != 1 || !bytes.Equal(c.KeyIDs[0], testKeyID) {
+		t.Errorf("got key IDs %x", c.KeyIDs)
+	}
+	if len(c.PSSHData) != 1 || c.PSSHData[0].GetProvider() != "widevine_test" {
+		t.Errorf("got PSSH data %v", c.PSSHData)
+	}
+	if c.PrivacyMode || c.Client == nil {
+		t.Fatalf("got privacy mode %v, client %v", c.PrivacyMode, c.Client)
+	}
+	if c.Client.MaxHDCP != HDCPV2_2 || c.Client.OEMCryptoAPIVersion != 15 || c.Client.Info["company_name"] != "Google" {
+		t.Errorf("got client hints %+v", c.Client)
+	}
+}
+
+func TestParseLicenseChallengeRenewal(t *testing.T) {
+	b := signedChallenge(t, &proto.LicenseRequest{
+		Type: proto.LicenseRequest_RENEWAL.Enum(),
+		ContentId: &proto.LicenseRequest_ContentIdentification{
+			ExistingLicense: &proto.LicenseRequest_ContentIdentification_ExistingLicense{
+				LicenseId: &proto.LicenseIdentification{
+					RequestId: []byte("request"),
+					SessionId: []byte("session"),
+				},
+			},
+		},
+		EncryptedClientId: &proto.EncryptedClientIdentification{
+			ProviderId: protobuf.String("widevine_test"),
+		},
+	})
+
+	c, err := ParseLicenseChallenge(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != RequestRenewal {
+		t.Errorf("got type %s, want RENEWAL", c.Type)
+	}
+	if string(c.SessionID) != "session" || c.ContentID != nil {
+		t.Errorf("got session ID %q, content ID %q", c.SessionID, c.ContentID)
+	}
+	if !c.PrivacyMode || c.Client != nil {
+		t.Errorf("got privacy mode %v, client %v", c.PrivacyMode, c.Client)
+	}
+}
+
+func TestParseLicenseChallengeCencDeprecated(t *testing.T) {
+	data, err := protobuf.Marshal(&proto.WidevinePsshData{
+		KeyIds:    [][]byte{testKeyID},
+		ContentId: []byte("testing"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := signedChallenge(t, &proto.LicenseRequest{
+		ContentId: &proto.LicenseRequest_ContentIdentification{
+			WidevinePsshData: &proto.LicenseRequest_ContentIdentification_CencDeprecated{
+				Pssh: [][]byte{data},
+			},
+		},
+	})
+
+	c, err := ParseLicenseChallenge(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != RequestNew || string(c.ContentID) != "testing" || len(c.KeyIDs) != 1 {
+		t.Errorf("got type %s, content ID %q, key IDs %x", c.Type, c.ContentID, c.KeyIDs)
+	}
+}
+
+func TestParseLicenseChallengeInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"garbage":             []byte("not a challenge"),
+		"service certificate": {0x08, 0x04},
+		"no content":          signedChallenge(t, &proto.LicenseRequest{}),
+	}
+	for name, b := range tests {
+		if _, err := ParseLicenseChallenge(b); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
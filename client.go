@@ -2,8 +2,9 @@ package widevine
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -11,61 +12,130 @@ import (
 )
 
 // HTTPClient defines an HTTP client.
+// Failed requests are retried according to Retry when it is set.
 type HTTPClient struct {
 	*http.Client
+	Retry *RetryPolicy
 }
 
 func (c *HTTPClient) get(url string, i interface{}) error {
-	rsp, e := c.Get(url)
-	if e != nil {
-		return e
-	}
+	return c.getContext(context.Background(), url, i)
+}
 
-	defer rsp.Body.Close()
+func (c *HTTPClient) getContext(ctx context.Context, url string, i interface{}) error {
+	return c.do(ctx, "GET", url, nil, i, true, nil)
+}
 
-	b, e := ioutil.ReadAll(rsp.Body)
+func (c *HTTPClient) post(url string, i interface{}, body interface{}) error {
+	return c.postContext(context.Background(), url, i, body)
+}
+
+// postContext sends a non-idempotent POST request, which is only retried
+// when it is known not to have been processed.
+func (c *HTTPClient) postContext(ctx context.Context, url string, i interface{}, body interface{}) error {
+	payload, e := json.Marshal(body)
 	if e != nil {
 		return e
 	}
-	if rsp.Status[0] != '2' {
-		return fmt.Errorf("expected status 2xx, got %s: %s", rsp.Status, string(b))
-	}
-
-	return json.Unmarshal(b, &i)
+	return c.do(ctx, "POST", url, payload, i, false, nil)
 }
 
-func (c *HTTPClient) post(url string, i interface{}, body interface{}) error {
-	payload, _ := json.Marshal(body)
-	req, e := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+// postIdempotentContext sends a POST request that is safe to repeat. When
+// retryable is set, it is called after a 2xx response was decoded into i and
+// reports whether the response is a transient failure to retry.
+func (c *HTTPClient) postIdempotentContext(ctx context.Context, url string, i interface{}, body interface{}, retryable func() bool) error {
+	payload, e := json.Marshal(body)
 	if e != nil {
 		return e
 	}
+	return c.do(ctx, "POST", url, payload, i, true, retryable)
+}
 
-	req.Header.Add("content-type", "application/json")
+// do sends the request, retrying it within a single budget of attempts.
+// When retries are exhausted after a 2xx response that retryable rejected,
+// nil is returned and i holds the last response.
+func (c *HTTPClient) do(ctx context.Context, method string, url string, payload []byte, i interface{}, idempotent bool, retryable func() bool) error {
+	var lastErr error
+	attempts := c.Retry.attempts()
 
-	rsp, e := http.DefaultClient.Do(req)
-	if e != nil {
-		return e
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		req, e := http.NewRequestWithContext(ctx, method, url, body)
+		if e != nil {
+			return e
+		}
+		if payload != nil {
+			req.Header.Add("content-type", "application/json")
+		}
+
+		wait := time.Duration(0)
+		rsp, e := c.Do(req)
+		if e != nil {
+			lastErr = &TransportError{URL: url, Err: e}
+			if attempt >= attempts || ctx.Err() != nil || !c.Retry.retryableError(e, idempotent) {
+				return lastErr
+			}
+		} else {
+			ok := rsp.StatusCode/100 == 2
+			retry := attempt < attempts && !ok && c.Retry.retryableStatus(rsp.StatusCode, idempotent)
+			lastErr = decodeResponse(url, rsp, i)
+			if attempt < attempts && ok && lastErr == nil && retryable != nil {
+				retry = retryable()
+			}
+			if !retry {
+				return lastErr
+			}
+			wait, _ = retryAfter(rsp.Header)
+			if c.Retry.MaxBackoff > 0 && wait > c.Retry.MaxBackoff {
+				wait = c.Retry.MaxBackoff
+			}
+		}
+
+		if wait == 0 {
+			wait = c.Retry.backoff(attempt)
+		}
+		if sleep(ctx, wait) != nil {
+			return lastErr
+		}
 	}
+}
 
+// decodeResponse reads and closes the response body, checks for a 2xx status
+// and unmarshals the JSON body into i. The body read is bound to the context
+// of the request that produced rsp.
+func decodeResponse(url string, rsp *http.Response, i interface{}) error {
 	defer rsp.Body.Close()
 
 	b, e := ioutil.ReadAll(rsp.Body)
 	if e != nil {
-		return e
+		return &TransportError{URL: url, Err: e}
 	}
-	if rsp.Status[0] != '2' {
-		return fmt.Errorf("expected status 2xx, got %s: %s", rsp.Status, string(b))
+	if rsp.StatusCode/100 != 2 {
+		return &HTTPStatusError{
+			URL:        url,
+			StatusCode: rsp.StatusCode,
+			Status:     rsp.Status,
+			Body:       b,
+		}
 	}
-	return json.Unmarshal(b, &i)
+	if e := json.Unmarshal(b, &i); e != nil {
+		return &DecodeError{Body: b, Err: e}
+	}
+	return nil
 }
 
+// defaultClient is shared by Widevine values that were not created with New.
+var defaultClient, _ = NewClient()
+
 // NewClient creates an HTTPClient instance.
 func NewClient() (*HTTPClient, error) {
 	var netTransport = &http.Transport{
-		Dial: (&net.Dialer{
+		DialContext: (&net.Dialer{
 			Timeout: 5 * time.Second,
-		}).Dial,
+		}).DialContext,
 		TLSHandshakeTimeout: 5 * time.Second,
 	}
 	return &HTTPClient{Client: &http.Client{
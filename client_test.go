@@ -1,10 +1,14 @@
 package widevine
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -63,3 +67,80 @@ func TestPost(t *testing.T) {
 		t.Error()
 	}
 }
+
+func TestPostStatusError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	resp := testResponse{}
+	client, _ := NewClient()
+	err := client.post(ts.URL, &resp, map[string]interface{}{})
+
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *HTTPStatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Error()
+	}
+}
+
+func TestPostDecodeError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `not json`)
+	}))
+	defer ts.Close()
+
+	resp := testResponse{}
+	client, _ := NewClient()
+	err := client.post(ts.URL, &resp, map[string]interface{}{})
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %v", err)
+	}
+}
+
+func TestGetTransportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := ts.URL
+	ts.Close()
+
+	resp := testResponse{}
+	client, _ := NewClient()
+	err := client.get(url, &resp)
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("expected *TransportError, got %v", err)
+	}
+}
+
+func TestPostContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp := testResponse{}
+	client, _ := NewClient()
+	err := client.postContext(ctx, server.URL, &resp, map[string]interface{}{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDecodeResponseEmptyStatus(t *testing.T) {
+	// Test doubles often leave Status empty and only set StatusCode.
+	rsp := &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{"test": "testing123"}`))}
+	resp := testResponse{}
+	if err := decodeResponse("http://example.com", rsp, &resp); err != nil || resp.Test != "testing123" {
+		t.Errorf("got %+v, %v", resp, err)
+	}
+
+	rsp = &http.Response{StatusCode: http.StatusBadGateway, Body: ioutil.NopCloser(strings.NewReader(""))}
+	var statusErr *HTTPStatusError
+	if err := decodeResponse("http://example.com", rsp, &resp); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected *HTTPStatusError, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package widevine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// KeyedSigner is a Signer that reports the ID of the key each signature was
+// made with. Widevine sets SigningKeyID of its responses from it.
+type KeyedSigner interface {
+	Signer
+	SignWithKeyID(ctx context.Context, message []byte) (signature, keyID string, err error)
+}
+
+// Credential is a provider signing key identified by ID.
+type Credential struct {
+	ID  string
+	Key []byte
+	IV  []byte
+}
+
+// CredentialState is the state of a Credential in a CredentialSet.
+type CredentialState string
+
+// Credential states.
+const (
+	CredentialActive   CredentialState = "ACTIVE"
+	CredentialStaged   CredentialState = "STAGED"
+	CredentialPrevious CredentialState = "PREVIOUS"
+)
+
+// CredentialSet is a KeyedSigner holding the signing keys of a provider
+// during a key rotation. Requests are signed with the active credential.
+// A staged credential is activated to roll forward; the credential it
+// replaces becomes previous and can be activated again to roll back.
+//
+// A CredentialSet is safe for concurrent use and can be switched while
+// requests are being signed.
+type CredentialSet struct {
+	mu     sync.RWMutex
+	creds  map[string]*Crypto
+	states map[string]CredentialState
+	active string
+}
+
+// NewCredentialSet returns a set signing with active.
+func NewCredentialSet(active Credential) (*CredentialSet, error) {
+	s := &CredentialSet{
+		creds:  make(map[string]*Crypto),
+		states: make(map[string]CredentialState),
+	}
+	if err := s.add(active, CredentialActive); err != nil {
+		return nil, err
+	}
+	s.active = active.ID
+	return s, nil
+}
+
+func (s *CredentialSet) add(c Credential, state CredentialState) error {
+	if c.ID == "" {
+		return errors.New("widevine: credential requires an ID")
+	}
+	if _, ok := s.creds[c.ID]; ok {
+		return fmt.Errorf("widevine: duplicate credential %q", c.ID)
+	}
+	crypto, err := NewCrypto(c.Key, c.IV)
+	if err != nil {
+		return fmt.Errorf("widevine: credential %q: %v", c.ID, err)
+	}
+	s.creds[c.ID] = crypto
+	s.states[c.ID] = state
+	return nil
+}
+
+// Stage adds c as a staged credential, to be activated later.
+func (s *CredentialSet) Stage(c Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(c, CredentialStaged)
+}
+
+// Activate makes the staged or previous credential id active. The
+// credential active until now becomes previous.
+func (s *CredentialSet) Activate(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.creds[id]; !ok {
+		return fmt.Errorf("widevine: unknown credential %q", id)
+	}
+	if id == s.active {
+		return nil
+	}
+	s.states[s.active] = CredentialPrevious
+	s.states[id] = CredentialActive
+	s.active = id
+	return nil
+}
+
+// Remove removes the credential id. The active credential cannot be
+// removed.
+func (s *CredentialSet) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == s.active {
+		return fmt.Errorf("widevine: cannot remove active credential %q", id)
+	}
+	if _, ok := s.creds[id]; !ok {
+		return fmt.Errorf("widevine: unknown credential %q", id)
+	}
+	delete(s.creds, id)
+	delete(s.states, id)
+	return nil
+}
+
+// ActiveID returns the ID of the active credential.
+func (s *CredentialSet) ActiveID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// States returns the state of every credential by ID.
+func (s *CredentialSet) States() map[string]CredentialState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	states := make(map[string]CredentialState, len(s.states))
+	for id, state := range s.states {
+		states[id] = state
+	}
+	return states
+}
+
+// Keys returns the keys of all credentials, for use in a KeyRegistry that
+// accepts every key of the rotation window.
+func (s *CredentialSet) Keys() []ProviderKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]ProviderKey, 0, len(s.creds))
+	for _, c := range s.creds {
+		keys = append(keys, ProviderKey{Key: c.Key, IV: c.IV})
+	}
+	return keys
+}
+
+// Sign signs message with the active credential.
+func (s *CredentialSet) Sign(ctx context.Context, message []byte) (string, error) {
+	sig, _, err := s.SignWithKeyID(ctx, message)
+	return sig, err
+}
+
+// SignWithKeyID signs message with the active credential and returns its ID.
+func (s *CredentialSet) SignWithKeyID(ctx context.Context, message []byte) (string, string, error) {
+	s.mu.RLock()
+	id, crypto := s.active, s.creds[s.active]
+	s.mu.RUnlock()
+
+	sig, err := crypto.Sign(ctx, message)
+	if err != nil {
+		return "", "", err
+	}
+	return sig, id, nil
+}
@@ -0,0 +1,154 @@
+package widevine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/alfg/widevine/widevinetest"
+)
+
+var nextKey = Credential{ID: "next", Key: make([]byte, 32), IV: make([]byte, 16)}
+
+func TestCredentialSetRotation(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+
+	creds, err := NewCredentialSet(Credential{ID: "current", Key: key, IV: iv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := creds.Stage(nextKey); err != nil {
+		t.Fatal(err)
+	}
+	wv := mustNew(t, Options{Provider: "widevine_test", Environment: Custom, URL: ts.URL, Signer: creds})
+
+	resp, err := wv.RequestLicense("testing", testLicenseChallenge)
+	if err != nil || resp.SigningKeyID != "current" {
+		t.Fatalf("got key ID %q, %v", resp.SigningKeyID, err)
+	}
+
+	// Roll forward to a key the server does not know yet.
+	if err := creds.Activate("next"); err != nil {
+		t.Fatal(err)
+	}
+	ckResp, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != StatusSignatureFailed {
+		t.Errorf("expected SIGNATURE_FAILED, got %v", err)
+	}
+	if ckResp.SigningKeyID != "next" {
+		t.Errorf("got key ID %q, want next", ckResp.SigningKeyID)
+	}
+	want := map[string]CredentialState{"current": CredentialPrevious, "next": CredentialActive}
+	for id, state := range creds.States() {
+		if want[id] != state {
+			t.Errorf("credential %s is %s, want %s", id, state, want[id])
+		}
+	}
+
+	// Roll back.
+	if err := creds.Activate("current"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := wv.RequestLicense("testing", testLicenseChallenge); err != nil || resp.SigningKeyID != "current" {
+		t.Errorf("got key ID %q, %v", resp.SigningKeyID, err)
+	}
+}
+
+func TestSigningKeyIDOnError(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+
+	creds, err := NewCredentialSet(Credential{ID: "current", Key: key, IV: iv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wv := mustNew(t, Options{Provider: "widevine_test", Environment: Custom, URL: ts.URL, Signer: creds})
+
+	ts.Enqueue(
+		widevinetest.Response{HTTPStatus: http.StatusBadGateway},
+		widevinetest.Response{Body: []byte("{not json")},
+		widevinetest.Response{HTTPStatus: http.StatusBadGateway},
+		widevinetest.Response{Body: []byte("{not json")},
+	)
+	for i := 0; i < 2; i++ {
+		if resp, err := wv.RequestLicense("testing", testLicenseChallenge); err == nil || resp.SigningKeyID != "current" {
+			t.Errorf("license: got key ID %q, %v", resp.SigningKeyID, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if resp, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}}); err == nil || resp.SigningKeyID != "current" {
+			t.Errorf("content key: got key ID %q, %v", resp.SigningKeyID, err)
+		}
+	}
+
+	// Transport errors.
+	wv.URL = "http://127.0.0.1:1"
+	if resp, err := wv.RequestLicense("testing", testLicenseChallenge); err == nil || resp.SigningKeyID != "current" {
+		t.Errorf("license: got key ID %q, %v", resp.SigningKeyID, err)
+	}
+	if resp, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}}); err == nil || resp.SigningKeyID != "current" {
+		t.Errorf("content key: got key ID %q, %v", resp.SigningKeyID, err)
+	}
+}
+
+func TestCredentialSetVerifier(t *testing.T) {
+	creds, err := NewCredentialSet(Credential{ID: "current", Key: key, IV: iv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := creds.Stage(nextKey); err != nil {
+		t.Fatal(err)
+	}
+	wv := mustNew(t, Options{Provider: "widevine_test", Signer: creds})
+	v := NewVerifier(StaticKeys{"widevine_test": creds.Keys()})
+
+	for _, id := range []string{"current", "next"} {
+		if err := creds.Activate(id); err != nil {
+			t.Fatal(err)
+		}
+		msg, err := wv.buildCKMessage(context.Background(), map[string]interface{}{"content_id": "dGVzdGluZw=="})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.KeyID != id {
+			t.Errorf("got key ID %q, want %q", msg.KeyID, id)
+		}
+		if _, err := v.VerifyEnvelope(msg.Envelope); err != nil {
+			t.Errorf("%s: %v", id, err)
+		}
+	}
+}
+
+func TestCredentialSetErrors(t *testing.T) {
+	if _, err := NewCredentialSet(Credential{ID: "short", Key: key[:16], IV: iv}); err == nil {
+		t.Error("expected error for invalid key")
+	}
+	if _, err := NewCredentialSet(Credential{Key: key, IV: iv}); err == nil {
+		t.Error("expected error for missing ID")
+	}
+
+	creds, err := NewCredentialSet(Credential{ID: "current", Key: key, IV: iv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := creds.Stage(Credential{ID: "current", Key: key, IV: iv}); err == nil {
+		t.Error("expected error for duplicate ID")
+	}
+	if err := creds.Activate("unknown"); err == nil {
+		t.Error("expected error activating unknown credential")
+	}
+	if err := creds.Remove("current"); err == nil {
+		t.Error("expected error removing active credential")
+	}
+
+	creds.Stage(nextKey)
+	if err := creds.Remove("next"); err != nil {
+		t.Fatal(err)
+	}
+	if len(creds.Keys()) != 1 || creds.ActiveID() != "current" {
+		t.Errorf("got %d keys, active %q", len(creds.Keys()), creds.ActiveID())
+	}
+}
@@ -2,65 +2,109 @@ package widevine
 
 import (
 	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/base64"
-	"encoding/hex"
 	"errors"
 	"fmt"
 )
 
-// Crypto struct.
+// Signer signs the JSON message of a request to Widevine Cloud. The
+// signature is sent base64 encoded in the "signature" field of the request.
+type Signer interface {
+	Sign(ctx context.Context, message []byte) (string, error)
+}
+
+// Sizes of the provider AES key and IV.
+const (
+	cryptoKeySize = 32
+	cryptoIVSize  = aes.BlockSize
+)
+
+// Crypto struct. It is the default Signer, signing with the provider AES key
+// and IV.
 type Crypto struct {
 	Key []byte
 	IV  []byte
 }
 
-// NewCrypto creates a Crypto instance with key and iv.
-func NewCrypto(key []byte, iv []byte) *Crypto {
+// NewCrypto creates a Crypto instance with key and iv. key must be 32 bytes
+// and iv 16 bytes.
+func NewCrypto(key []byte, iv []byte) (*Crypto, error) {
 	c := &Crypto{
 		Key: key,
 		IV:  iv,
 	}
-	return c
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
-func (c *Crypto) generateSignature(payload []byte) string {
-	h := sha1.New()
-	h.Write([]byte(payload))
-
-	bs := h.Sum(nil)
-	hash := fmt.Sprintf("%x", bs)
+func (c *Crypto) validate() error {
+	if len(c.Key) != cryptoKeySize {
+		return fmt.Errorf("widevine: key must be %d bytes, got %d", cryptoKeySize, len(c.Key))
+	}
+	if len(c.IV) != cryptoIVSize {
+		return fmt.Errorf("widevine: IV must be %d bytes, got %d", cryptoIVSize, len(c.IV))
+	}
+	return nil
+}
 
-	// Create signature.
-	ciphertext := c.encrypt(hash)
-	return ciphertext
+// Sign returns the AES-CBC encrypted SHA-1 hash of message.
+func (c *Crypto) Sign(ctx context.Context, message []byte) (string, error) {
+	return c.generateSignature(message)
 }
 
-func (c *Crypto) encrypt(text string) string {
-	// See: https://golang.org/pkg/crypto/cipher/#NewCBCEncrypter
+func (c *Crypto) generateSignature(payload []byte) (string, error) {
+	hash := sha1.Sum(payload)
 
-	textDec, _ := hex.DecodeString(text)
+	// Create signature.
+	return c.encrypt(hash[:])
+}
 
-	plaintext := pad([]byte(textDec))
+// verifySignature reports whether signature is the signature of payload.
+// The signature is recomputed and compared in constant time.
+func (c *Crypto) verifySignature(payload []byte, signature string) bool {
+	expected, err := c.generateSignature(payload)
+	if err != nil {
+		return false
+	}
+	want, err := base64.StdEncoding.DecodeString(expected)
+	if err != nil {
+		return false
+	}
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
 
-	if len(plaintext)%aes.BlockSize != 0 {
-		panic("plaintext is not a multiple of the block size")
+// block returns the AES cipher of the key after validating key and IV.
+func (c *Crypto) block() (cipher.Block, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
 	}
+	return aes.NewCipher(c.Key)
+}
 
-	block, err := aes.NewCipher(c.Key)
+// encrypt pads and encrypts plaintext and returns it base64 encoded.
+// See: https://golang.org/pkg/crypto/cipher/#NewCBCEncrypter
+func (c *Crypto) encrypt(plaintext []byte) (string, error) {
+	block, err := c.block()
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
-	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
-
+	padded := pad(plaintext)
+	ciphertext := make([]byte, len(padded))
 	mode := cipher.NewCBCEncrypter(block, c.IV)
-	mode.CryptBlocks(ciphertext[aes.BlockSize:], plaintext)
-
-	enc := base64.StdEncoding.EncodeToString([]byte(ciphertext[aes.BlockSize:]))
-	return enc
+	mode.CryptBlocks(ciphertext, padded)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
 // Pads src to a multiple of aes.Blocksize (16) using PKCS #7 standard block padding.
@@ -68,19 +112,28 @@ func (c *Crypto) encrypt(text string) string {
 func pad(src []byte) []byte {
 	padding := aes.BlockSize - len(src)%aes.BlockSize
 	padtext := bytes.Repeat([]byte{byte(padding)}, padding)
-	return append(src, padtext...)
+	return append(src[:len(src):len(src)], padtext...)
 }
 
+var errPadding = errors.New("widevine: invalid padding, possibly caused by an incorrect key")
+
 // Removes PKCS #7 standard block padding from src.
 // See http://tools.ietf.org/html/rfc5652#section-6.3.
 // This function is the inverse of pad.
-// If the padding is not well-formed, unpad returns nil.
+// If the padding is not well-formed, unpad returns an error.
 func unpad(src []byte) ([]byte, error) {
 	length := len(src)
+	if length == 0 || length%aes.BlockSize != 0 {
+		return nil, errPadding
+	}
 	unpadding := int(src[length-1])
-
-	if unpadding > length {
-		return nil, errors.New("unpad error. This could happen when incorrect encryption key is used")
+	if unpadding == 0 || unpadding > aes.BlockSize {
+		return nil, errPadding
+	}
+	for _, b := range src[length-unpadding:] {
+		if int(b) != unpadding {
+			return nil, errPadding
+		}
 	}
 	return src[:(length - unpadding)], nil
 }
@@ -1,6 +1,7 @@
 package widevine
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"testing"
@@ -41,12 +42,50 @@ func TestUnpad(t *testing.T) {
 	}
 }
 
-func TestEncrypt(t *testing.T) {
-	text := "supersecret"
+func TestUnpadInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":        {},
+		"not a block":  {1, 2, 3, 1},
+		"zero padding": append(make([]byte, 15), 0),
+		"too long":     append(make([]byte, 15), 17),
+		"inconsistent": append(make([]byte, 13), 1, 2, 3),
+	}
+	for name, b := range tests {
+		if _, err := unpad(b); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestNewCryptoInvalid(t *testing.T) {
+	if _, err := NewCrypto(key[:16], iv); err == nil {
+		t.Error("expected error for a 16 byte key")
+	}
+	if _, err := NewCrypto(key, iv[:8]); err == nil {
+		t.Error("expected error for an 8 byte IV")
+	}
+	if _, err := New(Options{Key: key[:31], IV: iv, Provider: "widevine_test"}); err == nil {
+		t.Error("expected error from New for a 31 byte key")
+	}
+
+	// A Crypto built without NewCrypto fails instead of panicking.
+	c := &Crypto{Key: []byte("short"), IV: iv}
+	if _, err := c.Sign(context.Background(), []byte("message")); err == nil {
+		t.Error("expected error signing with an invalid key")
+	}
+}
 
-	c := NewCrypto(key, iv)
-	enc := c.encrypt(text)
+func TestEncrypt(t *testing.T) {
+	c, err := NewCrypto(key, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
 
+	// A single block of padding.
+	enc, err := c.encrypt(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if enc != "8E2tY1KlW2830Q7EpsjS+A==" {
 		t.Error()
 	}
@@ -54,7 +93,10 @@ func TestEncrypt(t *testing.T) {
 
 func TestGenerateSignature(t *testing.T) {
 
-	c := NewCrypto(key, iv)
+	c, err := NewCrypto(key, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
 	payload := map[string]interface{}{
 		"test":   "testing",
 		"test2":  "testing2",
@@ -62,9 +104,43 @@ func TestGenerateSignature(t *testing.T) {
 		"isTest": true,
 	}
 	jsonPayload, _ := json.Marshal(payload)
-	sig := c.generateSignature(jsonPayload)
+	sig, err := c.generateSignature(jsonPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if sig != "ga80QzRuUM+jnPcoR6UWs5TXrTQ2VgeYiu0FoqCNRH4=" {
 		t.Error()
 	}
+	if !c.verifySignature(jsonPayload, sig) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	c, err := NewCrypto(key, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := c.generateSignature([]byte("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		payload   string
+		signature string
+		want      bool
+	}{
+		{"payload", sig, true},
+		{"other", sig, false},
+		{"payload", sig[:len(sig)-4], false},
+		{"payload", "not base64!", false},
+		{"payload", "", false},
+	}
+	for _, tt := range tests {
+		if got := c.verifySignature([]byte(tt.payload), tt.signature); got != tt.want {
+			t.Errorf("verifySignature(%q, %q) = %v, want %v", tt.payload, tt.signature, got, tt.want)
+		}
+	}
 }
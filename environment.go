@@ -0,0 +1,76 @@
+package widevine
+
+import (
+	"errors"
+	"strings"
+)
+
+// Environment selects which Widevine Cloud deployment requests are sent to.
+type Environment int
+
+// Widevine Cloud environments.
+const (
+	// Production sends requests to the production license service.
+	Production Environment = iota
+	// UAT sends requests to the user acceptance testing service.
+	UAT
+	// Custom sends requests to the base URL set in Options.URL.
+	Custom
+)
+
+// Default request paths. The content key path is followed by the provider.
+const (
+	defaultContentKeyPath = "/cenc/getcontentkey/"
+	defaultLicensePath    = "/cenc/getlicense"
+)
+
+var errMissingURL = errors.New("widevine: custom environment requires a URL")
+
+func (e Environment) String() string {
+	switch e {
+	case Production:
+		return "production"
+	case UAT:
+		return "uat"
+	case Custom:
+		return "custom"
+	}
+	return "unknown"
+}
+
+// baseURL returns the base URL for the configured environment. A non-empty
+// URL always takes precedence over the environment.
+func (wp *Widevine) baseURL() (string, error) {
+	if wp.URL != "" {
+		return strings.TrimSuffix(wp.URL, "/"), nil
+	}
+	switch wp.Environment {
+	case Production:
+		return widevineCloudURL, nil
+	case UAT:
+		return widevineCloudURLTest, nil
+	}
+	return "", errMissingURL
+}
+
+func (wp *Widevine) contentKeyURL() (string, error) {
+	base, err := wp.baseURL()
+	if err != nil {
+		return "", err
+	}
+	if wp.ContentKeyPath != "" {
+		return base + wp.ContentKeyPath, nil
+	}
+	return base + defaultContentKeyPath + wp.Provider, nil
+}
+
+func (wp *Widevine) licenseURL() (string, error) {
+	base, err := wp.baseURL()
+	if err != nil {
+		return "", err
+	}
+	if wp.LicensePath != "" {
+		return base + wp.LicensePath, nil
+	}
+	return base + defaultLicensePath, nil
+}
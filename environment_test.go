@@ -0,0 +1,48 @@
+package widevine
+
+import (
+	"testing"
+)
+
+func TestContentKeyURL(t *testing.T) {
+	tests := []struct {
+		wv   Widevine
+		want string
+	}{
+		{Widevine{Provider: "acme"}, "https://license.widevine.com/cenc/getcontentkey/acme"},
+		{Widevine{Provider: "acme", Environment: UAT}, "https://license.uat.widevine.com/cenc/getcontentkey/acme"},
+		{Widevine{Provider: "acme", Environment: Custom, URL: "http://localhost:8080/"}, "http://localhost:8080/cenc/getcontentkey/acme"},
+		{Widevine{Provider: "acme", URL: "http://localhost:8080", ContentKeyPath: "/keys"}, "http://localhost:8080/keys"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.wv.contentKeyURL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestLicenseURL(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "acme", Environment: UAT})
+	got, _ := wv.licenseURL()
+	if got != "https://license.uat.widevine.com/cenc/getlicense" {
+		t.Error(got)
+	}
+
+	wv = mustNew(t, Options{Key: key, IV: iv, Provider: "acme", URL: "http://localhost:8080", LicensePath: "/license"})
+	got, _ = wv.licenseURL()
+	if got != "http://localhost:8080/license" {
+		t.Error(got)
+	}
+}
+
+func TestCustomEnvironmentWithoutURL(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "acme", Environment: Custom})
+	if _, err := wv.licenseURL(); err != errMissingURL {
+		t.Errorf("expected errMissingURL, got %v", err)
+	}
+}
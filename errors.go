@@ -0,0 +1,63 @@
+package widevine
+
+import (
+	"fmt"
+)
+
+// TransportError is returned when a request to Widevine Cloud could not be
+// sent or its response could not be read.
+type TransportError struct {
+	URL string
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("widevine: request to %s failed: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying network error.
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// HTTPStatusError is returned when Widevine Cloud responds with a non-2xx
+// HTTP status.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("widevine: expected status 2xx, got %s: %s", e.Status, string(e.Body))
+}
+
+// DecodeError is returned when a response from Widevine Cloud could not be
+// decoded.
+type DecodeError struct {
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("widevine: unable to decode response: %v", e.Err)
+}
+
+// Unwrap returns the underlying base64 or JSON error.
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// StatusError is returned when Widevine Cloud processed the request but
+// responded with a status other than "OK".
+type StatusError struct {
+	Status         Status
+	InternalStatus int
+}
+
+func (e *StatusError) Error() string {
+	if e.InternalStatus != 0 {
+		return fmt.Sprintf("widevine: status %s (internal status %d)", e.Status, e.InternalStatus)
+	}
+	return fmt.Sprintf("widevine: status %s", e.Status)
+}
+
+// Retryable reports whether the request may succeed when sent again.
+func (e *StatusError) Retryable() bool { return e.Status.Retryable() }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/alfg/widevine"
 )
@@ -24,11 +25,15 @@ func main() {
 
 	// Set Widevine options and create instance.
 	options := widevine.Options{
-		Key:      key,
-		IV:       iv,
-		Provider: "widevine_test",
+		Key:         key,
+		IV:          iv,
+		Provider:    "widevine_test",
+		Environment: widevine.UAT,
+	}
+	wv, err := widevine.New(options)
+	if err != nil {
+		log.Fatal(err)
 	}
-	wv := widevine.New(options)
 
 	// Your video content ID, usually a GUID.
 	contentID := "testing"
@@ -1,10 +1,9 @@
 package main
 
 import (
-	"encoding/base64"
-	"fmt"
-	"io/ioutil"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/alfg/widevine"
 )
@@ -29,36 +28,36 @@ const contentID = "fkj3ljaSdfalkr3j"
 
 func main() {
 
-	// Create handler and http server.
-	http.HandleFunc("/proxy", proxy)
-	http.ListenAndServe(":8000", nil)
-}
-
-func proxy(w http.ResponseWriter, r *http.Request) {
-
-	// Read bytes from license request.
-	buf, _ := ioutil.ReadAll(r.Body)
-	body := base64.StdEncoding.EncodeToString(buf)
-
 	// Set Widevine options and create instance.
 	options := widevine.Options{
-		Key:      key,
-		IV:       iv,
-		Provider: "widevine_test",
+		Key:         key,
+		IV:          iv,
+		Provider:    "widevine_test",
+		Environment: widevine.UAT,
+	}
+	wv, err := widevine.New(options)
+	if err != nil {
+		log.Fatal(err)
 	}
-	wv := widevine.New(options)
-
-	// Create license request.
-	data := wv.GetLicense(contentID, body)
-	b, _ := base64.StdEncoding.DecodeString(data.License)
-
-	// Log any additional details from response.
-	fmt.Println(data.LicenseMetadata)
-	fmt.Println(data.SupportedTracks)
 
-	// CORS required for Javascript players.
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	// Create the license proxy handler. The content ID is read from the
+	// "content_id" query parameter, falling back to the test content ID.
+	proxy := widevine.NewHandler(wv, widevine.HandlerOptions{
+		ResolveContentID: func(r *http.Request) (string, error) {
+			if id := r.URL.Query().Get("content_id"); id != "" {
+				return id, nil
+			}
+			return contentID, nil
+		},
+		// CORS required for Javascript players.
+		AllowedOrigins: []string{"http://localhost:8080"},
+		Timeout:        10 * time.Second,
+		// Service certificate requests of privacy mode players are answered
+		// with the certificate fetched from Widevine Cloud. Set
+		// ServiceCertificate to serve a locally stored copy instead.
+	})
 
-	// Write decoded license bytes back to player.
-	w.Write(b)
+	// Create handler and http server.
+	http.Handle("/proxy", proxy)
+	log.Fatal(http.ListenAndServe(":8000", nil))
 }
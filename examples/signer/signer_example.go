@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alfg/widevine"
+)
+
+// AES key and IV for the provider "widevine_test".
+// Use these test keys for testing or integration tests.
+var (
+	key = []byte{
+		0x1a, 0xe8, 0xcc, 0xd0, 0xe7, 0x98, 0x5c, 0xc0,
+		0xb6, 0x20, 0x3a, 0x55, 0x85, 0x5a, 0x10, 0x34,
+		0xaf, 0xc2, 0x52, 0x98, 0x0e, 0x97, 0x0c, 0xa9,
+		0x0e, 0x52, 0x02, 0x68, 0x9f, 0x94, 0x7a, 0xb9}
+
+	iv = []byte{
+		0xd5, 0x8c, 0xe9, 0x54, 0x20, 0x3b, 0x7c, 0x9a,
+		0x9a, 0x9d, 0x46, 0x7f, 0x59, 0x83, 0x92, 0x49}
+)
+
+// UnixSigner is a widevine.Signer delegating signatures to a signing
+// service listening on a Unix socket. The service receives the message in
+// the body of "POST /sign" and answers with the base64 signature.
+type UnixSigner struct {
+	client *http.Client
+}
+
+// NewUnixSigner returns a signer using the service listening on path.
+func NewUnixSigner(path string) *UnixSigner {
+	return &UnixSigner{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+// Sign implements widevine.Signer.
+func (s *UnixSigner) Sign(ctx context.Context, message []byte) (string, error) {
+	req, err := http.NewRequest("POST", "http://signer/sign", bytes.NewReader(message))
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New("signer: " + strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
+
+// serveSigner runs a signing service on path. In production this is a
+// separate daemon with access to the HSM holding the provider key.
+func serveSigner(path string) error {
+	signer, err := widevine.NewCrypto(key, iv)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	go http.Serve(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig, err := signer.Sign(r.Context(), message)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, sig)
+	}))
+	return nil
+}
+
+func main() {
+	dir, err := ioutil.TempDir("", "widevine-signer")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "signer.sock")
+	if err := serveSigner(socket); err != nil {
+		log.Fatal(err)
+	}
+
+	// The Widevine instance never sees the provider key.
+	options := widevine.Options{
+		Provider:    "widevine_test",
+		Environment: widevine.UAT,
+		Signer:      NewUnixSigner(socket),
+	}
+	wv, err := widevine.New(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	policy := widevine.Policy{
+		Tracks:   []string{"SD", "HD", "AUDIO"},
+		DRMTypes: []string{"WIDEVINE"},
+		Policy:   "default",
+	}
+	resp, err := wv.RequestContentKey("testing", policy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("status: ", resp.Status)
+}
@@ -0,0 +1,84 @@
+package widevine
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// Size of an AES-128 content key.
+const contentKeySize = 16
+
+// ExternalKey is a content key generated outside of Widevine Cloud for a
+// track type such as "SD", "HD" or "AUDIO".
+type ExternalKey struct {
+	TrackType string
+	KeyID     []byte
+	Key       []byte
+}
+
+func (k ExternalKey) validate() error {
+	if k.TrackType == "" {
+		return errors.New("widevine: external key requires a track type")
+	}
+	if len(k.KeyID) != keyIDSize {
+		return fmt.Errorf("widevine: key ID for track %s must be 16 bytes", k.TrackType)
+	}
+	if len(k.Key) != contentKeySize {
+		return fmt.Errorf("widevine: key for track %s must be 16 bytes", k.TrackType)
+	}
+	return nil
+}
+
+// RegisterContentKeys registers caller supplied content keys for contentID
+// with Widevine Cloud instead of having Widevine generate them. The
+// response carries the PSSH generated for the keys. Policy.Tracks is
+// ignored; the track types are taken from keys.
+func (wp *Widevine) RegisterContentKeys(contentID string, keys []ExternalKey, policy Policy) (GetContentKeyResponse, error) {
+	return wp.RegisterContentKeysContext(context.Background(), contentID, keys, policy)
+}
+
+// RegisterContentKeysContext is like RegisterContentKeys but uses ctx for
+// the request.
+func (wp *Widevine) RegisterContentKeysContext(ctx context.Context, contentID string, keys []ExternalKey, policy Policy) (GetContentKeyResponse, error) {
+	if len(keys) == 0 {
+		return GetContentKeyResponse{}, errors.New("widevine: no external keys")
+	}
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		if err := k.validate(); err != nil {
+			return GetContentKeyResponse{}, err
+		}
+		if seen[k.TrackType] {
+			return GetContentKeyResponse{}, fmt.Errorf("widevine: duplicate external key for track %s", k.TrackType)
+		}
+		seen[k.TrackType] = true
+	}
+	if err := ValidateProtectionScheme(policy.ProtectionScheme, policy.DRMTypes); err != nil {
+		return GetContentKeyResponse{}, err
+	}
+
+	p := wp.setPolicy(contentID, policy)
+	p["tracks"] = externalTracks(keys)
+
+	msg, err := wp.buildCKMessage(ctx, p)
+	if err != nil {
+		return GetContentKeyResponse{}, err
+	}
+	return wp.getContentKeyRequest(ctx, msg)
+}
+
+// externalTracks builds the tracks of a content key request with base64
+// encoded key IDs and keys.
+func externalTracks(keys []ExternalKey) []interface{} {
+	var tracks []interface{}
+	for _, k := range keys {
+		tracks = append(tracks, map[string]string{
+			"type":   k.TrackType,
+			"key_id": base64.StdEncoding.EncodeToString(k.KeyID),
+			"key":    base64.StdEncoding.EncodeToString(k.Key),
+		})
+	}
+	return tracks
+}
@@ -0,0 +1,72 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testContentKey = []byte{
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+	0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+
+func TestRegisterContentKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var envelope map[string]string
+		json.NewDecoder(r.Body).Decode(&envelope)
+		if envelope["signer"] != "widevine_test" || envelope["signature"] == "" {
+			t.Error("expected signed request")
+		}
+
+		dec, _ := base64.StdEncoding.DecodeString(envelope["request"])
+		var req struct {
+			Tracks []map[string]string `json:"tracks"`
+		}
+		json.Unmarshal(dec, &req)
+		if len(req.Tracks) != 1 || req.Tracks[0]["type"] != "SD" ||
+			req.Tracks[0]["key_id"] != base64.StdEncoding.EncodeToString(testKeyID) ||
+			req.Tracks[0]["key"] != base64.StdEncoding.EncodeToString(testContentKey) {
+			t.Errorf("unexpected tracks %v", req.Tracks)
+		}
+
+		resp, _ := json.Marshal(map[string]interface{}{
+			"status": "OK",
+			"tracks": []map[string]interface{}{{
+				"type":   "SD",
+				"key_id": req.Tracks[0]["key_id"],
+				"pssh":   []map[string]string{{"drm_type": "WIDEVINE", "data": "CAESEG5aHSYnV0fXgEbqpdHTS1o="}},
+			}},
+		})
+		json.NewEncoder(w).Encode(map[string]string{"response": base64.StdEncoding.EncodeToString(resp)})
+	}))
+	defer ts.Close()
+
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: ts.URL})
+	keys := []ExternalKey{{TrackType: "SD", KeyID: testKeyID, Key: testContentKey}}
+	resp, err := wv.RegisterContentKeys("testing", keys, Policy{DRMTypes: []string{"WIDEVINE"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tracks) != 1 || resp.Tracks[0].PSSH[0].Data == "" {
+		t.Error("expected PSSH in response")
+	}
+}
+
+func TestRegisterContentKeysInvalid(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: "http://127.0.0.1:0"})
+
+	tests := map[string][]ExternalKey{
+		"empty":     nil,
+		"track":     {{KeyID: testKeyID, Key: testContentKey}},
+		"key id":    {{TrackType: "SD", KeyID: testKeyID[:8], Key: testContentKey}},
+		"key":       {{TrackType: "SD", KeyID: testKeyID, Key: testContentKey[:8]}},
+		"duplicate": {{TrackType: "SD", KeyID: testKeyID, Key: testContentKey}, {TrackType: "SD", KeyID: testKeyID, Key: testContentKey}},
+	}
+	for name, keys := range tests {
+		if _, err := wv.RegisterContentKeys("testing", keys, Policy{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
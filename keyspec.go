@@ -0,0 +1,210 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// TrackType is a Widevine track type.
+type TrackType string
+
+// Widevine track types.
+const (
+	TrackSD    TrackType = "SD"
+	TrackHD    TrackType = "HD"
+	TrackUHD1  TrackType = "UHD1"
+	TrackUHD2  TrackType = "UHD2"
+	TrackAudio TrackType = "AUDIO"
+)
+
+// Valid reports whether t is a known track type.
+func (t TrackType) Valid() bool {
+	switch t {
+	case TrackSD, TrackHD, TrackUHD1, TrackUHD2, TrackAudio:
+		return true
+	}
+	return false
+}
+
+// AllowedTrackTypes limits the track types a license grants keys for.
+// AUDIO is granted with every value.
+type AllowedTrackTypes string
+
+// Allowed track types.
+const (
+	SDOnly AllowedTrackTypes = "SD_ONLY"
+	SDHD   AllowedTrackTypes = "SD_HD"
+	SDUHD1 AllowedTrackTypes = "SD_UHD1"
+	SDUHD2 AllowedTrackTypes = "SD_UHD2"
+)
+
+// defaultAllowedTrackTypes is sent when neither LicenseOptions nor Options
+// set allowed track types.
+const defaultAllowedTrackTypes = SDUHD1
+
+// Valid reports whether a is a known allowed track types value.
+func (a AllowedTrackTypes) Valid() bool {
+	switch a {
+	case SDOnly, SDHD, SDUHD1, SDUHD2:
+		return true
+	}
+	return false
+}
+
+// TrackTypes returns the track types granted by a.
+func (a AllowedTrackTypes) TrackTypes() []TrackType {
+	switch a {
+	case SDOnly:
+		return []TrackType{TrackAudio, TrackSD}
+	case SDHD:
+		return []TrackType{TrackAudio, TrackSD, TrackHD}
+	case SDUHD1:
+		return []TrackType{TrackAudio, TrackSD, TrackHD, TrackUHD1}
+	case SDUHD2:
+		return []TrackType{TrackAudio, TrackSD, TrackHD, TrackUHD1, TrackUHD2}
+	}
+	return nil
+}
+
+// SecurityLevel is the minimum client robustness required for a track.
+// Widevine L1 devices support HWSecureAll, L3 devices SWSecureDecode.
+type SecurityLevel int
+
+// Widevine security levels, from least to most robust.
+const (
+	SWSecureCrypto SecurityLevel = iota + 1
+	SWSecureDecode
+	HWSecureCrypto
+	HWSecureDecode
+	HWSecureAll
+)
+
+// HDCP is the HDCP version required on digital outputs.
+type HDCP string
+
+// HDCP requirements.
+const (
+	HDCPNone            HDCP = "HDCP_NONE"
+	HDCPV1              HDCP = "HDCP_V1"
+	HDCPV2              HDCP = "HDCP_V2"
+	HDCPV2_1            HDCP = "HDCP_V2_1"
+	HDCPV2_2            HDCP = "HDCP_V2_2"
+	HDCPV2_3            HDCP = "HDCP_V2_3"
+	HDCPNoDigitalOutput HDCP = "HDCP_NO_DIGITAL_OUTPUT"
+)
+
+// CGMS is the CGMS-A copy control setting for analog outputs.
+type CGMS string
+
+// CGMS-A settings.
+const (
+	CGMSNone      CGMS = "CGMS_NONE"
+	CGMSCopyFree  CGMS = "COPY_FREE"
+	CGMSCopyOnce  CGMS = "COPY_ONCE"
+	CGMSCopyNever CGMS = "COPY_NEVER"
+)
+
+// OutputProtection lists the output protection a client must enforce to
+// play a track. Empty fields leave the requirement to the policy.
+type OutputProtection struct {
+	HDCP                HDCP
+	CGMS                CGMS
+	DisableAnalogOutput bool
+}
+
+// ContentKeySpec describes one track type in a license request.
+//
+// KeyID selects the key of the track and Key optionally supplies the key
+// itself, for content encrypted with external keys. A zero SecurityLevel
+// leaves the requirement to the policy.
+type ContentKeySpec struct {
+	TrackType                TrackType
+	KeyID                    []byte
+	Key                      []byte
+	SecurityLevel            SecurityLevel
+	RequiredOutputProtection *OutputProtection
+}
+
+func (s ContentKeySpec) validate() error {
+	if !s.TrackType.Valid() {
+		return fmt.Errorf("widevine: unknown track type %q", s.TrackType)
+	}
+	if s.KeyID != nil && len(s.KeyID) != keyIDSize {
+		return fmt.Errorf("widevine: key ID for track %s must be 16 bytes", s.TrackType)
+	}
+	if s.Key != nil {
+		if s.KeyID == nil {
+			return fmt.Errorf("widevine: key for track %s requires a key ID", s.TrackType)
+		}
+		if len(s.Key) != contentKeySize {
+			return fmt.Errorf("widevine: key for track %s must be 16 bytes", s.TrackType)
+		}
+	}
+	if s.SecurityLevel != 0 && (s.SecurityLevel < SWSecureCrypto || s.SecurityLevel > HWSecureAll) {
+		return fmt.Errorf("widevine: invalid security level %d for track %s", s.SecurityLevel, s.TrackType)
+	}
+	if op := s.RequiredOutputProtection; op != nil {
+		switch op.HDCP {
+		case "", HDCPNone, HDCPV1, HDCPV2, HDCPV2_1, HDCPV2_2, HDCPV2_3, HDCPNoDigitalOutput:
+		default:
+			return fmt.Errorf("widevine: unknown HDCP requirement %q for track %s", op.HDCP, s.TrackType)
+		}
+		switch op.CGMS {
+		case "", CGMSNone, CGMSCopyFree, CGMSCopyOnce, CGMSCopyNever:
+		default:
+			return fmt.Errorf("widevine: unknown CGMS setting %q for track %s", op.CGMS, s.TrackType)
+		}
+	}
+	return nil
+}
+
+func (s ContentKeySpec) spec() map[string]interface{} {
+	spec := map[string]interface{}{
+		"track_type": s.TrackType,
+	}
+	if s.KeyID != nil {
+		spec["key_id"] = base64.StdEncoding.EncodeToString(s.KeyID)
+	}
+	if s.Key != nil {
+		spec["key"] = base64.StdEncoding.EncodeToString(s.Key)
+	}
+	if s.SecurityLevel != 0 {
+		spec["security_level"] = s.SecurityLevel
+	}
+	if op := s.RequiredOutputProtection; op != nil {
+		protection := map[string]interface{}{}
+		if op.HDCP != "" {
+			protection["hdcp"] = op.HDCP
+		}
+		if op.CGMS != "" {
+			protection["cgms_flags"] = op.CGMS
+		}
+		if op.DisableAnalogOutput {
+			protection["disable_analog_output"] = true
+		}
+		spec["required_output_protection"] = protection
+	}
+	return spec
+}
+
+func validateContentKeySpecs(specs []ContentKeySpec) error {
+	seen := make(map[TrackType]bool)
+	for _, s := range specs {
+		if err := s.validate(); err != nil {
+			return err
+		}
+		if seen[s.TrackType] {
+			return fmt.Errorf("widevine: duplicate content key spec for track %s", s.TrackType)
+		}
+		seen[s.TrackType] = true
+	}
+	return nil
+}
+
+func contentKeySpecs(specs []ContentKeySpec) []interface{} {
+	var out []interface{}
+	for _, s := range specs {
+		out = append(out, s.spec())
+	}
+	return out
+}
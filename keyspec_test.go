@@ -0,0 +1,142 @@
+package widevine
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestContentKeySpecsOutputProtection(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	opts := LicenseOptions{
+		ContentKeySpecs: []ContentKeySpec{
+			{
+				TrackType:                TrackUHD1,
+				SecurityLevel:            HWSecureAll,
+				RequiredOutputProtection: &OutputProtection{HDCP: HDCPV2_2},
+			},
+			{
+				TrackType:                TrackSD,
+				RequiredOutputProtection: &OutputProtection{CGMS: CGMSCopyNever, DisableAnalogOutput: true},
+			},
+		},
+	}
+	if err := opts.validate(); err != nil {
+		t.Fatal(err)
+	}
+	msg, _ := wv.buildLicenseMessage(context.Background(), "testing", "", opts)
+
+	dec, _ := base64.StdEncoding.DecodeString(msg.Request)
+	var req struct {
+		ContentKeySpecs []struct {
+			TrackType                string `json:"track_type"`
+			SecurityLevel            int    `json:"security_level"`
+			RequiredOutputProtection struct {
+				HDCP                string `json:"hdcp"`
+				CGMSFlags           string `json:"cgms_flags"`
+				DisableAnalogOutput bool   `json:"disable_analog_output"`
+			} `json:"required_output_protection"`
+		} `json:"content_key_specs"`
+	}
+	json.Unmarshal(dec, &req)
+
+	specs := req.ContentKeySpecs
+	if len(specs) != 2 {
+		t.Fatalf("unexpected request %s", dec)
+	}
+	if specs[0].TrackType != "UHD1" || specs[0].SecurityLevel != 5 || specs[0].RequiredOutputProtection.HDCP != "HDCP_V2_2" {
+		t.Errorf("unexpected UHD1 spec %+v", specs[0])
+	}
+	if specs[1].RequiredOutputProtection.CGMSFlags != "COPY_NEVER" || !specs[1].RequiredOutputProtection.DisableAnalogOutput {
+		t.Errorf("unexpected SD spec %+v", specs[1])
+	}
+}
+
+func TestContentKeySpecsValidate(t *testing.T) {
+	tests := map[string][]ContentKeySpec{
+		"track":     {{TrackType: "4K"}},
+		"level":     {{TrackType: TrackHD, SecurityLevel: 6}},
+		"hdcp":      {{TrackType: TrackHD, RequiredOutputProtection: &OutputProtection{HDCP: "HDCP_V3"}}},
+		"cgms":      {{TrackType: TrackHD, RequiredOutputProtection: &OutputProtection{CGMS: "COPY"}}},
+		"duplicate": {{TrackType: TrackHD}, {TrackType: TrackHD}},
+		"key id":    {{TrackType: TrackHD, KeyID: testKeyID[:4]}},
+		"key":       {{TrackType: TrackHD, KeyID: testKeyID, Key: testContentKey[:4]}},
+		"no key id": {{TrackType: TrackHD, Key: testContentKey}},
+	}
+	for name, specs := range tests {
+		if err := validateContentKeySpecs(specs); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestContentKeySpecsKeys(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	opts := LicenseOptions{
+		ContentKeySpecs: []ContentKeySpec{
+			{TrackType: TrackAudio, KeyID: testKeyID, SecurityLevel: SWSecureCrypto},
+			{TrackType: TrackSD, KeyID: testKeyID, Key: testContentKey, SecurityLevel: SWSecureDecode},
+			{TrackType: TrackUHD2, SecurityLevel: HWSecureAll},
+		},
+	}
+	if err := opts.validate(); err != nil {
+		t.Fatal(err)
+	}
+	msg, _ := wv.buildLicenseMessage(context.Background(), "testing", "", opts)
+
+	dec, _ := base64.StdEncoding.DecodeString(msg.Request)
+	var req struct {
+		ContentKeySpecs []map[string]interface{} `json:"content_key_specs"`
+	}
+	json.Unmarshal(dec, &req)
+
+	specs := req.ContentKeySpecs
+	kid := base64.StdEncoding.EncodeToString(testKeyID)
+	if len(specs) != 3 {
+		t.Fatalf("unexpected request %s", dec)
+	}
+	if specs[0]["key_id"] != kid || specs[0]["key"] != nil {
+		t.Errorf("unexpected AUDIO spec %v", specs[0])
+	}
+	if specs[1]["key"] != base64.StdEncoding.EncodeToString(testContentKey) {
+		t.Errorf("unexpected SD spec %v", specs[1])
+	}
+	if specs[2]["key_id"] != nil || specs[2]["security_level"] != float64(5) {
+		t.Errorf("unexpected UHD2 spec %v", specs[2])
+	}
+}
+
+func TestAllowedTrackTypes(t *testing.T) {
+	decode := func(msg *signedEnvelope) string {
+		dec, _ := base64.StdEncoding.DecodeString(msg.Request)
+		var req struct {
+			AllowedTrackTypes string `json:"allowed_track_types"`
+		}
+		json.Unmarshal(dec, &req)
+		return req.AllowedTrackTypes
+	}
+
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	msg, _ := wv.buildLicenseMessage(context.Background(), "testing", "", LicenseOptions{})
+	if got := decode(msg); got != "SD_UHD1" {
+		t.Errorf("expected default SD_UHD1, got %s", got)
+	}
+
+	msg, _ = wv.buildLicenseMessage(context.Background(), "testing", "", LicenseOptions{AllowedTrackTypes: SDOnly})
+	if got := decode(msg); got != "SD_ONLY" {
+		t.Errorf("expected SD_ONLY, got %s", got)
+	}
+
+	if len(SDHD.TrackTypes()) != 3 || SDUHD2.TrackTypes()[4] != TrackUHD2 {
+		t.Error()
+	}
+}
+
+func TestAllowedTrackTypesValidate(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", AllowedTrackTypes: "SD_UHD3"})
+	if _, err := wv.RequestLicense("testing", ""); err == nil || !strings.Contains(err.Error(), "SD_UHD3") {
+		t.Errorf("expected allowed track types error, got %v", err)
+	}
+}
@@ -1,11 +1,10 @@
 package widevine
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
-
-	"github.com/alfg/widevine/proto"
-	protobuf "github.com/golang/protobuf/proto"
+	"net/http"
 )
 
 // Widevine Cloud URLs.
@@ -16,164 +15,226 @@ const (
 
 // Widevine structure.
 type Widevine struct {
-	Key      []byte
-	IV       []byte
-	Provider string
-	URL      string
+	Key               []byte
+	IV                []byte
+	Provider          string
+	URL               string
+	Environment       Environment
+	ContentKeyPath    string
+	LicensePath       string
+	AllowedTrackTypes AllowedTrackTypes
+	Signer            Signer
+
+	client *HTTPClient
 }
 
 // Options provided to Widevine{} instance.
+//
+// Requests go to the Widevine Cloud service selected by Environment unless
+// URL is set, in which case URL is used as the base URL. ContentKeyPath
+// defaults to "/cenc/getcontentkey/<provider>" and LicensePath defaults to
+// "/cenc/getlicense".
+//
+// HTTPClient replaces the client created by NewClient. Transport replaces
+// the transport of the client created by NewClient and is ignored when
+// HTTPClient is set. Retry enables retries of failed calls, see RetryPolicy.
+//
+// AllowedTrackTypes is the default of license requests, SD_UHD1 when empty.
+//
+// Signer signs all requests, e.g. with a key held in an HSM. Key and IV are
+// not needed when it is set; by default requests are signed with a Crypto
+// using Key and IV.
 type Options struct {
-	Key      []byte
-	IV       []byte
-	Provider string
-	URL      string
+	Key               []byte
+	IV                []byte
+	Provider          string
+	URL               string
+	Environment       Environment
+	ContentKeyPath    string
+	LicensePath       string
+	HTTPClient        *http.Client
+	Transport         http.RoundTripper
+	Retry             *RetryPolicy
+	AllowedTrackTypes AllowedTrackTypes
+	Signer            Signer
 }
 
 // Policy struct to set policy options for a ContentKey request.
+// ProtectionScheme is optional and must be supported by every DRM type.
 type Policy struct {
-	ContentID string
-	Tracks    []string
-	DRMTypes  []string
-	Policy    string
-}
-
-// GetContentKeyResponse JSON response from Widevine Cloud.
-// /cenc/getcontentkey/<provider>
-type GetContentKeyResponse struct {
-	Status      string   `json:"status"`
-	DRM         []drm    `json:"drm"`
-	Tracks      []tracks `json:"tracks"`
-	AlreadyUsed bool     `json:"already_used"`
-}
-
-type drm struct {
-	Type     string `json:"type"`
-	SystemID string `json:"system_id"`
-}
-
-type tracks struct {
-	Type  string `json:"type"`
-	KeyID string `json:"key_id"`
-	Key   string `json:"key"`
-	PSSH  []pssh `json:"pssh"`
-}
-
-type pssh struct {
-	DRMType string `json:"drm_type"`
-	Data    string `json:"data"`
-}
-
-// GetLicenseResponse decoded JSON response from Widevine Cloud.
-// /cenc/getlicense
-type GetLicenseResponse struct {
-	Status                     string            `json:"status"`
-	License                    string            `json:"license"`
-	Make                       string            `json:"make"`
-	Model                      string            `json:"model"`
-	SecurityLevel              int               `json:"security_level"`
-	InternalStatus             int               `json:"internal_status"`
-	DRMCertSerialNumber        string            `json:"drm_cert_serial_number"`
-	DeviceWhitelistState       string            `json:"device_whitelist_state"`
-	MessageType                string            `json:"message_type"`
-	Platform                   string            `json:"platform"`
-	DeviceState                string            `json:"device_state"`
-	ClientMaxHDCPVersion       string            `json:"client_max_hdcp_version"`
-	PlatformVerificationStatus string            `json:"platform_verification_status"`
-	ContentOwner               string            `json:"content_owner"`
-	ContentPRovider            string            `json:"content_provider"`
-	SessionState               sessionState      `json:"session_state"`
-	LicenseMetadata            licenseMetadata   `json:"license_metadata"`
-	SupportedTracks            []supportedTracks `json:"supported_tracks"`
-	PSSHData                   psshData          `json:"pssh_data"`
-	ClientInfo                 []clientInfo      `json:"client_info"`
-}
-type licenseMetadata struct {
-	ContentID   string `json:"content_id"`
-	LicenseType string `json:"license_type"`
-	RequestType string `json:"request_type"`
-}
-
-type supportedTracks struct {
-	Type  string `json:"type"`
-	KeyID string `json:"key_id"`
-}
-type sessionState struct {
-	LicenseID      licenseID `json:"license_id"`
-	SigningKey     string    `json:"signing_key"`
-	KeyboxSystemID int       `json:"keybox_system_id"`
-	LicenseCounter int       `json:"license_counter"`
-}
-type licenseID struct {
-	RequestID  string `json:"request_id"`
-	SessionID  string `json:"session_id"`
-	PurchaseID string `json:"purchase_id"`
-	Type       string `json:"type"`
-	Version    int    `json:"version"`
-}
-
-type psshData struct {
-	KeyID     string `json:"key_id"`
-	ContentID string `json:"content_id"`
-}
-type clientInfo struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	ContentID        string
+	Tracks           []string
+	DRMTypes         []string
+	Policy           string
+	ProtectionScheme ProtectionScheme
 }
 
 // New returns a Widevine instance with options.
-func New(opts Options) *Widevine {
+// The instance reuses a single HTTP client for all of its requests.
+// Without a Signer, Key must be 32 bytes and IV 16 bytes.
+func New(opts Options) (*Widevine, error) {
+	if opts.Signer == nil {
+		if _, err := NewCrypto(opts.Key, opts.IV); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := NewClient()
+	if err != nil {
+		return nil, err
+	}
+	if opts.HTTPClient != nil {
+		client = &HTTPClient{Client: opts.HTTPClient}
+	} else if opts.Transport != nil {
+		client.Transport = opts.Transport
+	}
+	client.Retry = opts.Retry
 
 	wv := &Widevine{
-		Key:      opts.Key,
-		IV:       opts.IV,
-		Provider: opts.Provider,
+		Key:               opts.Key,
+		IV:                opts.IV,
+		Provider:          opts.Provider,
+		URL:               opts.URL,
+		Environment:       opts.Environment,
+		ContentKeyPath:    opts.ContentKeyPath,
+		LicensePath:       opts.LicensePath,
+		AllowedTrackTypes: opts.AllowedTrackTypes,
+		Signer:            opts.Signer,
+		client:            client,
+	}
+	return wv, nil
+}
+
+func (wp *Widevine) httpClient() *HTTPClient {
+	if wp.client == nil {
+		return defaultClient
 	}
-	return wv
+	return wp.client
+}
+
+func (wp *Widevine) signer() (Signer, error) {
+	if wp.Signer == nil {
+		return NewCrypto(wp.Key, wp.IV)
+	}
+	return wp.Signer, nil
 }
 
 // GetContentKey creates a content key giving a contentID.
+// Errors are discarded; use RequestContentKey to inspect them.
 func (wp *Widevine) GetContentKey(contentID string, policy Policy) GetContentKeyResponse {
-	p := wp.setPolicy(contentID, policy)
-	msg := wp.buildCKMessage(p)
-	resp := wp.getContentKeyRequest(msg)
+	return wp.GetContentKeyContext(context.Background(), contentID, policy)
+}
 
-	// TODO
-	// Build custom PSSH from protobuf.
-	// enc := wp.buildPSSH(contentID)
-	// fmt.Println("pssh  build:", enc)
+// GetContentKeyContext is like GetContentKey but uses ctx for the request.
+func (wp *Widevine) GetContentKeyContext(ctx context.Context, contentID string, policy Policy) GetContentKeyResponse {
+	resp, _ := wp.RequestContentKeyContext(ctx, contentID, policy)
 	return resp
 }
 
+// RequestContentKey creates a content key giving a contentID and returns any
+// transport, HTTP status, decode or Widevine status error.
+// A *StatusError is returned along with the decoded response.
+func (wp *Widevine) RequestContentKey(contentID string, policy Policy) (GetContentKeyResponse, error) {
+	return wp.RequestContentKeyContext(context.Background(), contentID, policy)
+}
+
+// RequestContentKeyContext is like RequestContentKey but uses ctx for the
+// request. Cancelling ctx aborts the dial, the request and the response read.
+func (wp *Widevine) RequestContentKeyContext(ctx context.Context, contentID string, policy Policy) (GetContentKeyResponse, error) {
+	if err := ValidateProtectionScheme(policy.ProtectionScheme, policy.DRMTypes); err != nil {
+		return GetContentKeyResponse{}, err
+	}
+
+	p := wp.setPolicy(contentID, policy)
+	msg, err := wp.buildCKMessage(ctx, p)
+	if err != nil {
+		return GetContentKeyResponse{}, err
+	}
+	return wp.getContentKeyRequest(ctx, msg)
+}
+
 // GetLicense creates a license request used with a proxy server.
+// Errors are discarded; use RequestLicense to inspect them.
 func (wp *Widevine) GetLicense(contentID string, body string) GetLicenseResponse {
-	msg := wp.buildLicenseMessage(contentID, body)
-	resp := wp.getLicenseRequest(msg)
+	return wp.GetLicenseContext(context.Background(), contentID, body)
+}
+
+// GetLicenseContext is like GetLicense but uses ctx for the request.
+func (wp *Widevine) GetLicenseContext(ctx context.Context, contentID string, body string) GetLicenseResponse {
+	resp, _ := wp.RequestLicenseContext(ctx, contentID, body)
 	return resp
 }
 
-func (wp *Widevine) buildPSSH(contentID string) string {
-	wvpssh := &proto.WidevineCencHeader{
-		Provider:  protobuf.String(wp.Provider),
-		ContentId: []byte(contentID),
+// RequestLicense creates a license request used with a proxy server and
+// returns any transport, HTTP status, decode or Widevine status error.
+// A *StatusError is returned along with the decoded response.
+func (wp *Widevine) RequestLicense(contentID string, body string) (GetLicenseResponse, error) {
+	return wp.RequestLicenseContext(context.Background(), contentID, body)
+}
+
+// RequestLicenseContext is like RequestLicense but uses ctx for the request.
+// Cancelling ctx aborts the dial, the request and the response read.
+func (wp *Widevine) RequestLicenseContext(ctx context.Context, contentID string, body string) (GetLicenseResponse, error) {
+	return wp.RequestLicenseWithOptions(ctx, contentID, body, LicenseOptions{})
+}
+
+// RequestLicenseWithOptions is like RequestLicenseContext but applies opts to
+// the request. opts is validated before the request is signed.
+func (wp *Widevine) RequestLicenseWithOptions(ctx context.Context, contentID string, body string, opts LicenseOptions) (GetLicenseResponse, error) {
+	if opts.AllowedTrackTypes == "" {
+		opts.AllowedTrackTypes = wp.AllowedTrackTypes
 	}
-	p, _ := protobuf.Marshal(wvpssh)
-	return base64.StdEncoding.EncodeToString(p)
+	if err := opts.validate(); err != nil {
+		return GetLicenseResponse{}, err
+	}
+
+	msg, err := wp.buildLicenseMessage(ctx, contentID, body, opts)
+	if err != nil {
+		return GetLicenseResponse{}, err
+	}
+	return wp.getLicenseRequest(ctx, msg)
 }
 
-func (wp *Widevine) buildCKMessage(policy map[string]interface{}) map[string]interface{} {
+func (wp *Widevine) buildCKMessage(ctx context.Context, policy map[string]interface{}) (*signedEnvelope, error) {
 	// Marshal and encode payload.
-	jsonPayload, _ := json.Marshal(policy)
-	b64payload := base64.StdEncoding.EncodeToString([]byte(jsonPayload))
+	jsonPayload, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	return wp.sign(ctx, jsonPayload)
+}
+
+// signedEnvelope is a signed request body and the ID of the signing key,
+// when the signer reports it.
+type signedEnvelope struct {
+	Envelope
+	KeyID string `json:"-"`
+}
 
-	// Create signature and postBody.
-	crypto := NewCrypto(wp.Key, wp.IV)
-	postBody := map[string]interface{}{
-		"request":   b64payload,
-		"signature": crypto.generateSignature(jsonPayload),
-		"signer":    wp.Provider,
+// sign signs the JSON message of a request and returns the request body.
+func (wp *Widevine) sign(ctx context.Context, message []byte) (*signedEnvelope, error) {
+	signer, err := wp.signer()
+	if err != nil {
+		return nil, err
 	}
-	return postBody
+
+	var signature, keyID string
+	if ks, ok := signer.(KeyedSigner); ok {
+		signature, keyID, err = ks.SignWithKeyID(ctx, message)
+	} else {
+		signature, err = signer.Sign(ctx, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &signedEnvelope{
+		Envelope: Envelope{
+			Request:   base64.StdEncoding.EncodeToString(message),
+			Signature: signature,
+			Signer:    wp.Provider,
+		},
+		KeyID: keyID,
+	}, nil
 }
 
 func (wp *Widevine) setPolicy(contentID string, policy Policy) map[string]interface{} {
@@ -193,63 +254,86 @@ func (wp *Widevine) setPolicy(contentID string, policy Policy) map[string]interf
 		"drm_types":  policy.DRMTypes,
 		"policy":     policy.Policy,
 	}
+	if policy.ProtectionScheme != 0 {
+		p["protection_scheme"] = uint32(policy.ProtectionScheme)
+	}
 	return p
 }
 
-func (wp *Widevine) buildLicenseMessage(contentID string, body string) map[string]interface{} {
+func (wp *Widevine) buildLicenseMessage(ctx context.Context, contentID string, body string, opts LicenseOptions) (*signedEnvelope, error) {
 	enc := base64.StdEncoding.EncodeToString([]byte(contentID))
 
+	allowed := opts.AllowedTrackTypes
+	if allowed == "" {
+		allowed = defaultAllowedTrackTypes
+	}
+
 	message := map[string]interface{}{
 		"payload":             body,
 		"content_id":          enc,
 		"provider":            wp.Provider,
-		"allowed_track_types": "SD_UHD1",
+		"allowed_track_types": allowed,
 	}
-	jsonMessage, _ := json.Marshal(message)
-	b64message := base64.StdEncoding.EncodeToString(jsonMessage)
-
-	// Create signature and postBody.
-	crypto := NewCrypto(wp.Key, wp.IV)
-	postBody := map[string]interface{}{
-		"request":   b64message,
-		"signature": crypto.generateSignature(jsonMessage),
-		"signer":    wp.Provider,
+	if opts.Policy != "" {
+		message["policy"] = opts.Policy
+	}
+	if opts.PolicyOverrides != nil {
+		message["policy_overrides"] = opts.PolicyOverrides.overrides()
+	}
+	if len(opts.ContentKeySpecs) > 0 {
+		message["content_key_specs"] = contentKeySpecs(opts.ContentKeySpecs)
+	}
+	jsonMessage, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
 	}
-	return postBody
+	return wp.sign(ctx, jsonMessage)
 }
 
-func (wp *Widevine) getContentKeyRequest(body map[string]interface{}) GetContentKeyResponse {
-	// Set production or test portal.
-	var url string
-	if wp.Provider == "widevine_test" {
-		url = widevineCloudURLTest + "/cenc/getcontentkey/widevine_test"
-	} else {
-		url = widevineCloudURL + "/cenc/getcontentkey/" + wp.Provider
+// getContentKeyRequest sends a content key request. Content key requests
+// are idempotent, so a retryable Widevine status is retried according to
+// the retry policy of the client, within the same attempts as HTTP errors.
+func (wp *Widevine) getContentKeyRequest(ctx context.Context, body *signedEnvelope) (GetContentKeyResponse, error) {
+	output := GetContentKeyResponse{SigningKeyID: body.KeyID}
+	url, err := wp.contentKeyURL()
+	if err != nil {
+		return output, err
 	}
 
 	// Make client call.
 	resp := make(map[string]string)
-	client, _ := NewClient()
-	client.post(url, &resp, body)
+	retryable := func() bool {
+		var r struct {
+			Status Status `json:"status"`
+		}
+		dec, err := base64.StdEncoding.DecodeString(resp["response"])
+		return err == nil && json.Unmarshal(dec, &r) == nil && r.Status.Retryable()
+	}
+	if err := wp.httpClient().postIdempotentContext(ctx, url, &resp, body, retryable); err != nil {
+		return output, err
+	}
 
 	// Decode and unmarshal the response.
-	dec, _ := base64.StdEncoding.DecodeString(resp["response"])
-	output := GetContentKeyResponse{}
-	json.Unmarshal(dec, &output)
-	return output
+	dec, err := base64.StdEncoding.DecodeString(resp["response"])
+	if err != nil {
+		return output, &DecodeError{Body: []byte(resp["response"]), Err: err}
+	}
+	if err := json.Unmarshal(dec, &output); err != nil {
+		return output, &DecodeError{Body: dec, Err: err}
+	}
+	return output, output.Err()
 }
 
-func (wp *Widevine) getLicenseRequest(body map[string]interface{}) GetLicenseResponse {
-	// Set production or test portal.
-	var url string
-	if wp.Provider == "widevine_test" {
-		url = widevineCloudURLTest + "/cenc/getlicense"
-	} else {
-		url = widevineCloudURL + "/cenc/getlicense"
+func (wp *Widevine) getLicenseRequest(ctx context.Context, body *signedEnvelope) (GetLicenseResponse, error) {
+	url, err := wp.licenseURL()
+	if err != nil {
+		return GetLicenseResponse{SigningKeyID: body.KeyID}, err
 	}
+
 	// Make client call.
-	resp := GetLicenseResponse{}
-	client, _ := NewClient()
-	client.post(url, &resp, body)
-	return resp
+	resp := GetLicenseResponse{SigningKeyID: body.KeyID}
+	if err := wp.httpClient().postContext(ctx, url, &resp, body); err != nil {
+		return resp, err
+	}
+	return resp, resp.Err()
 }
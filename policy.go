@@ -0,0 +1,127 @@
+package widevine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// LicenseOptions sets per-request options of a license request.
+//
+// Policy names a policy registered in the Widevine portal and
+// PolicyOverrides overrides individual fields of it for this request.
+// ContentKeySpecs describes each track type with its own key ID, key,
+// security level and output protection, so that e.g. AUDIO and SD can be
+// granted to L3 devices and UHD1 to L1 devices only. AllowedTrackTypes
+// overrides Options.AllowedTrackTypes for this request.
+type LicenseOptions struct {
+	Policy            string
+	PolicyOverrides   *LicensePolicy
+	ContentKeySpecs   []ContentKeySpec
+	AllowedTrackTypes AllowedTrackTypes
+}
+
+func (o LicenseOptions) validate() error {
+	if o.AllowedTrackTypes != "" && !o.AllowedTrackTypes.Valid() {
+		return fmt.Errorf("widevine: unknown allowed track types %q", o.AllowedTrackTypes)
+	}
+	if o.PolicyOverrides != nil {
+		if err := o.PolicyOverrides.validate(); err != nil {
+			return err
+		}
+	}
+	return validateContentKeySpecs(o.ContentKeySpecs)
+}
+
+// LicensePolicy overrides the policy of a single license request.
+//
+// Nil flags and zero durations leave the value of the named policy
+// unchanged. Durations are sent with a resolution of one second.
+type LicensePolicy struct {
+	CanPlay    *bool
+	CanPersist *bool
+	CanRenew   *bool
+
+	RentalDuration          time.Duration
+	PlaybackDuration        time.Duration
+	LicenseDuration         time.Duration
+	RenewalRecoveryDuration time.Duration
+
+	RenewalServerURL     string
+	RenewalDelay         time.Duration
+	RenewalRetryInterval time.Duration
+	RenewWithUsage       *bool
+
+	SoftEnforcePlaybackDuration *bool
+	SoftEnforceRentalDuration   *bool
+}
+
+// Bool returns a pointer to v, for use with LicensePolicy flags.
+func Bool(v bool) *bool {
+	return &v
+}
+
+func (p *LicensePolicy) validate() error {
+	durations := map[string]time.Duration{
+		"rental duration":           p.RentalDuration,
+		"playback duration":         p.PlaybackDuration,
+		"license duration":          p.LicenseDuration,
+		"renewal recovery duration": p.RenewalRecoveryDuration,
+		"renewal delay":             p.RenewalDelay,
+		"renewal retry interval":    p.RenewalRetryInterval,
+	}
+	for name, d := range durations {
+		if d < 0 {
+			return fmt.Errorf("widevine: policy %s must not be negative", name)
+		}
+		if d > 0 && d < time.Second {
+			return fmt.Errorf("widevine: policy %s must be at least one second", name)
+		}
+	}
+
+	if p.RenewalServerURL != "" {
+		u, err := url.Parse(p.RenewalServerURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("widevine: invalid renewal server URL %q", p.RenewalServerURL)
+		}
+	}
+
+	renewal := p.RenewalServerURL != "" || p.RenewalDelay > 0 || p.RenewalRetryInterval > 0
+	if renewal && p.CanRenew != nil && !*p.CanRenew {
+		return errors.New("widevine: renewal settings require can_renew")
+	}
+	return nil
+}
+
+// overrides returns the policy_overrides object of a license request.
+func (p *LicensePolicy) overrides() map[string]interface{} {
+	o := make(map[string]interface{})
+	setBool := func(name string, v *bool) {
+		if v != nil {
+			o[name] = *v
+		}
+	}
+	setSeconds := func(name string, d time.Duration) {
+		if d > 0 {
+			o[name] = int64(d / time.Second)
+		}
+	}
+
+	setBool("can_play", p.CanPlay)
+	setBool("can_persist", p.CanPersist)
+	setBool("can_renew", p.CanRenew)
+	setSeconds("rental_duration_seconds", p.RentalDuration)
+	setSeconds("playback_duration_seconds", p.PlaybackDuration)
+	setSeconds("license_duration_seconds", p.LicenseDuration)
+	setSeconds("renewal_recovery_duration_seconds", p.RenewalRecoveryDuration)
+	if p.RenewalServerURL != "" {
+		o["renewal_server_url"] = p.RenewalServerURL
+	}
+	setSeconds("renewal_delay_seconds", p.RenewalDelay)
+	setSeconds("renewal_retry_interval_seconds", p.RenewalRetryInterval)
+	setBool("renew_with_usage", p.RenewWithUsage)
+	setBool("soft_enforce_playback_duration", p.SoftEnforcePlaybackDuration)
+	setBool("soft_enforce_rental_duration", p.SoftEnforceRentalDuration)
+	return o
+}
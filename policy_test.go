@@ -0,0 +1,76 @@
+package widevine
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLicensePolicyOverrides(t *testing.T) {
+	p := &LicensePolicy{
+		CanPlay:          Bool(true),
+		CanPersist:       Bool(false),
+		CanRenew:         Bool(true),
+		LicenseDuration:  48 * time.Hour,
+		RenewalServerURL: "https://example.com/renew",
+		RenewalDelay:     90 * time.Second,
+	}
+	if err := p.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	o := p.overrides()
+	want := map[string]interface{}{
+		"can_play":                 true,
+		"can_persist":              false,
+		"can_renew":                true,
+		"license_duration_seconds": int64(172800),
+		"renewal_server_url":       "https://example.com/renew",
+		"renewal_delay_seconds":    int64(90),
+	}
+	if len(o) != len(want) {
+		t.Errorf("got %v, want %v", o, want)
+	}
+	for k, v := range want {
+		if o[k] != v {
+			t.Errorf("%s: got %v, want %v", k, o[k], v)
+		}
+	}
+}
+
+func TestLicensePolicyValidate(t *testing.T) {
+	tests := map[string]*LicensePolicy{
+		"negative":     {PlaybackDuration: -time.Second},
+		"subsecond":    {RentalDuration: time.Millisecond},
+		"url":          {RenewalServerURL: "example.com/renew"},
+		"cannot renew": {CanRenew: Bool(false), RenewalDelay: time.Minute},
+	}
+	for name, p := range tests {
+		if err := p.validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBuildLicenseMessagePolicy(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	msg, err := wv.buildLicenseMessage(context.Background(), "testing", "", LicenseOptions{
+		Policy:          "rental",
+		PolicyOverrides: &LicensePolicy{CanPersist: Bool(true)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec, _ := base64.StdEncoding.DecodeString(msg.Request)
+	var req struct {
+		Policy          string                 `json:"policy"`
+		PolicyOverrides map[string]interface{} `json:"policy_overrides"`
+	}
+	json.Unmarshal(dec, &req)
+	if req.Policy != "rental" || req.PolicyOverrides["can_persist"] != true {
+		t.Errorf("unexpected request %s", dec)
+	}
+}
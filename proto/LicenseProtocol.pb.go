@@ -0,0 +1,1384 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: LicenseProtocol.proto
+
+/*
+Package proto is a generated protocol buffer package.
+
+It is generated from these files:
+
+	LicenseProtocol.proto
+	WidevineCencHeader.proto
+
+It has these top-level messages:
+
+	SignedMessage
+	LicenseIdentification
+	LicenseRequest
+	ClientIdentification
+	EncryptedClientIdentification
+	WidevinePsshData
+	WidevineCencHeader
+*/
+package proto
+
+import proto1 "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto1.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type LicenseType int32
+
+const (
+	LicenseType_STREAMING LicenseType = 1
+	LicenseType_OFFLINE   LicenseType = 2
+	// License type decision is left to the provider.
+	LicenseType_AUTOMATIC LicenseType = 3
+)
+
+var LicenseType_name = map[int32]string{
+	1: "STREAMING",
+	2: "OFFLINE",
+	3: "AUTOMATIC",
+}
+var LicenseType_value = map[string]int32{
+	"STREAMING": 1,
+	"OFFLINE":   2,
+	"AUTOMATIC": 3,
+}
+
+func (x LicenseType) Enum() *LicenseType {
+	p := new(LicenseType)
+	*p = x
+	return p
+}
+func (x LicenseType) String() string {
+	return proto1.EnumName(LicenseType_name, int32(x))
+}
+func (x *LicenseType) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(LicenseType_value, data, "LicenseType")
+	if err != nil {
+		return err
+	}
+	*x = LicenseType(value)
+	return nil
+}
+func (LicenseType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type ProtocolVersion int32
+
+const (
+	ProtocolVersion_VERSION_2_0 ProtocolVersion = 20
+	ProtocolVersion_VERSION_2_1 ProtocolVersion = 21
+	ProtocolVersion_VERSION_2_2 ProtocolVersion = 22
+)
+
+var ProtocolVersion_name = map[int32]string{
+	20: "VERSION_2_0",
+	21: "VERSION_2_1",
+	22: "VERSION_2_2",
+}
+var ProtocolVersion_value = map[string]int32{
+	"VERSION_2_0": 20,
+	"VERSION_2_1": 21,
+	"VERSION_2_2": 22,
+}
+
+func (x ProtocolVersion) Enum() *ProtocolVersion {
+	p := new(ProtocolVersion)
+	*p = x
+	return p
+}
+func (x ProtocolVersion) String() string {
+	return proto1.EnumName(ProtocolVersion_name, int32(x))
+}
+func (x *ProtocolVersion) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(ProtocolVersion_value, data, "ProtocolVersion")
+	if err != nil {
+		return err
+	}
+	*x = ProtocolVersion(value)
+	return nil
+}
+func (ProtocolVersion) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type SignedMessage_MessageType int32
+
+const (
+	SignedMessage_LICENSE_REQUEST             SignedMessage_MessageType = 1
+	SignedMessage_LICENSE                     SignedMessage_MessageType = 2
+	SignedMessage_ERROR_RESPONSE              SignedMessage_MessageType = 3
+	SignedMessage_SERVICE_CERTIFICATE_REQUEST SignedMessage_MessageType = 4
+	SignedMessage_SERVICE_CERTIFICATE         SignedMessage_MessageType = 5
+	SignedMessage_SUB_LICENSE                 SignedMessage_MessageType = 6
+)
+
+var SignedMessage_MessageType_name = map[int32]string{
+	1: "LICENSE_REQUEST",
+	2: "LICENSE",
+	3: "ERROR_RESPONSE",
+	4: "SERVICE_CERTIFICATE_REQUEST",
+	5: "SERVICE_CERTIFICATE",
+	6: "SUB_LICENSE",
+}
+var SignedMessage_MessageType_value = map[string]int32{
+	"LICENSE_REQUEST":             1,
+	"LICENSE":                     2,
+	"ERROR_RESPONSE":              3,
+	"SERVICE_CERTIFICATE_REQUEST": 4,
+	"SERVICE_CERTIFICATE":         5,
+	"SUB_LICENSE":                 6,
+}
+
+func (x SignedMessage_MessageType) Enum() *SignedMessage_MessageType {
+	p := new(SignedMessage_MessageType)
+	*p = x
+	return p
+}
+func (x SignedMessage_MessageType) String() string {
+	return proto1.EnumName(SignedMessage_MessageType_name, int32(x))
+}
+func (x *SignedMessage_MessageType) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(SignedMessage_MessageType_value, data, "SignedMessage_MessageType")
+	if err != nil {
+		return err
+	}
+	*x = SignedMessage_MessageType(value)
+	return nil
+}
+func (SignedMessage_MessageType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{0, 0}
+}
+
+type LicenseRequest_RequestType int32
+
+const (
+	LicenseRequest_NEW     LicenseRequest_RequestType = 1
+	LicenseRequest_RENEWAL LicenseRequest_RequestType = 2
+	LicenseRequest_RELEASE LicenseRequest_RequestType = 3
+)
+
+var LicenseRequest_RequestType_name = map[int32]string{
+	1: "NEW",
+	2: "RENEWAL",
+	3: "RELEASE",
+}
+var LicenseRequest_RequestType_value = map[string]int32{
+	"NEW":     1,
+	"RENEWAL": 2,
+	"RELEASE": 3,
+}
+
+func (x LicenseRequest_RequestType) Enum() *LicenseRequest_RequestType {
+	p := new(LicenseRequest_RequestType)
+	*p = x
+	return p
+}
+func (x LicenseRequest_RequestType) String() string {
+	return proto1.EnumName(LicenseRequest_RequestType_name, int32(x))
+}
+func (x *LicenseRequest_RequestType) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(LicenseRequest_RequestType_value, data, "LicenseRequest_RequestType")
+	if err != nil {
+		return err
+	}
+	*x = LicenseRequest_RequestType(value)
+	return nil
+}
+func (LicenseRequest_RequestType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0}
+}
+
+type LicenseRequest_ContentIdentification_InitData_InitDataType int32
+
+const (
+	LicenseRequest_ContentIdentification_InitData_CENC LicenseRequest_ContentIdentification_InitData_InitDataType = 1
+	LicenseRequest_ContentIdentification_InitData_WEBM LicenseRequest_ContentIdentification_InitData_InitDataType = 2
+)
+
+var LicenseRequest_ContentIdentification_InitData_InitDataType_name = map[int32]string{
+	1: "CENC",
+	2: "WEBM",
+}
+var LicenseRequest_ContentIdentification_InitData_InitDataType_value = map[string]int32{
+	"CENC": 1,
+	"WEBM": 2,
+}
+
+func (x LicenseRequest_ContentIdentification_InitData_InitDataType) Enum() *LicenseRequest_ContentIdentification_InitData_InitDataType {
+	p := new(LicenseRequest_ContentIdentification_InitData_InitDataType)
+	*p = x
+	return p
+}
+func (x LicenseRequest_ContentIdentification_InitData_InitDataType) String() string {
+	return proto1.EnumName(LicenseRequest_ContentIdentification_InitData_InitDataType_name, int32(x))
+}
+func (x *LicenseRequest_ContentIdentification_InitData_InitDataType) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(LicenseRequest_ContentIdentification_InitData_InitDataType_value, data, "LicenseRequest_ContentIdentification_InitData_InitDataType")
+	if err != nil {
+		return err
+	}
+	*x = LicenseRequest_ContentIdentification_InitData_InitDataType(value)
+	return nil
+}
+func (LicenseRequest_ContentIdentification_InitData_InitDataType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0, 3, 0}
+}
+
+type ClientIdentification_TokenType int32
+
+const (
+	ClientIdentification_KEYBOX                         ClientIdentification_TokenType = 0
+	ClientIdentification_DRM_DEVICE_CERTIFICATE         ClientIdentification_TokenType = 1
+	ClientIdentification_REMOTE_ATTESTATION_CERTIFICATE ClientIdentification_TokenType = 2
+	ClientIdentification_OEM_DEVICE_CERTIFICATE         ClientIdentification_TokenType = 3
+)
+
+var ClientIdentification_TokenType_name = map[int32]string{
+	0: "KEYBOX",
+	1: "DRM_DEVICE_CERTIFICATE",
+	2: "REMOTE_ATTESTATION_CERTIFICATE",
+	3: "OEM_DEVICE_CERTIFICATE",
+}
+var ClientIdentification_TokenType_value = map[string]int32{
+	"KEYBOX":                         0,
+	"DRM_DEVICE_CERTIFICATE":         1,
+	"REMOTE_ATTESTATION_CERTIFICATE": 2,
+	"OEM_DEVICE_CERTIFICATE":         3,
+}
+
+func (x ClientIdentification_TokenType) Enum() *ClientIdentification_TokenType {
+	p := new(ClientIdentification_TokenType)
+	*p = x
+	return p
+}
+func (x ClientIdentification_TokenType) String() string {
+	return proto1.EnumName(ClientIdentification_TokenType_name, int32(x))
+}
+func (x *ClientIdentification_TokenType) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(ClientIdentification_TokenType_value, data, "ClientIdentification_TokenType")
+	if err != nil {
+		return err
+	}
+	*x = ClientIdentification_TokenType(value)
+	return nil
+}
+func (ClientIdentification_TokenType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 0}
+}
+
+type ClientIdentification_ClientCapabilities_HdcpVersion int32
+
+const (
+	ClientIdentification_ClientCapabilities_HDCP_NONE              ClientIdentification_ClientCapabilities_HdcpVersion = 0
+	ClientIdentification_ClientCapabilities_HDCP_V1                ClientIdentification_ClientCapabilities_HdcpVersion = 1
+	ClientIdentification_ClientCapabilities_HDCP_V2                ClientIdentification_ClientCapabilities_HdcpVersion = 2
+	ClientIdentification_ClientCapabilities_HDCP_V2_1              ClientIdentification_ClientCapabilities_HdcpVersion = 3
+	ClientIdentification_ClientCapabilities_HDCP_V2_2              ClientIdentification_ClientCapabilities_HdcpVersion = 4
+	ClientIdentification_ClientCapabilities_HDCP_V2_3              ClientIdentification_ClientCapabilities_HdcpVersion = 5
+	ClientIdentification_ClientCapabilities_HDCP_NO_DIGITAL_OUTPUT ClientIdentification_ClientCapabilities_HdcpVersion = 255
+)
+
+var ClientIdentification_ClientCapabilities_HdcpVersion_name = map[int32]string{
+	0:   "HDCP_NONE",
+	1:   "HDCP_V1",
+	2:   "HDCP_V2",
+	3:   "HDCP_V2_1",
+	4:   "HDCP_V2_2",
+	5:   "HDCP_V2_3",
+	255: "HDCP_NO_DIGITAL_OUTPUT",
+}
+var ClientIdentification_ClientCapabilities_HdcpVersion_value = map[string]int32{
+	"HDCP_NONE":              0,
+	"HDCP_V1":                1,
+	"HDCP_V2":                2,
+	"HDCP_V2_1":              3,
+	"HDCP_V2_2":              4,
+	"HDCP_V2_3":              5,
+	"HDCP_NO_DIGITAL_OUTPUT": 255,
+}
+
+func (x ClientIdentification_ClientCapabilities_HdcpVersion) Enum() *ClientIdentification_ClientCapabilities_HdcpVersion {
+	p := new(ClientIdentification_ClientCapabilities_HdcpVersion)
+	*p = x
+	return p
+}
+func (x ClientIdentification_ClientCapabilities_HdcpVersion) String() string {
+	return proto1.EnumName(ClientIdentification_ClientCapabilities_HdcpVersion_name, int32(x))
+}
+func (x *ClientIdentification_ClientCapabilities_HdcpVersion) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(ClientIdentification_ClientCapabilities_HdcpVersion_value, data, "ClientIdentification_ClientCapabilities_HdcpVersion")
+	if err != nil {
+		return err
+	}
+	*x = ClientIdentification_ClientCapabilities_HdcpVersion(value)
+	return nil
+}
+func (ClientIdentification_ClientCapabilities_HdcpVersion) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 1, 0}
+}
+
+type ClientIdentification_ClientCapabilities_AnalogOutputCapabilities int32
+
+const (
+	ClientIdentification_ClientCapabilities_ANALOG_OUTPUT_UNKNOWN         ClientIdentification_ClientCapabilities_AnalogOutputCapabilities = 0
+	ClientIdentification_ClientCapabilities_ANALOG_OUTPUT_NONE            ClientIdentification_ClientCapabilities_AnalogOutputCapabilities = 1
+	ClientIdentification_ClientCapabilities_ANALOG_OUTPUT_SUPPORTED       ClientIdentification_ClientCapabilities_AnalogOutputCapabilities = 2
+	ClientIdentification_ClientCapabilities_ANALOG_OUTPUT_SUPPORTS_CGMS_A ClientIdentification_ClientCapabilities_AnalogOutputCapabilities = 3
+)
+
+var ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_name = map[int32]string{
+	0: "ANALOG_OUTPUT_UNKNOWN",
+	1: "ANALOG_OUTPUT_NONE",
+	2: "ANALOG_OUTPUT_SUPPORTED",
+	3: "ANALOG_OUTPUT_SUPPORTS_CGMS_A",
+}
+var ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_value = map[string]int32{
+	"ANALOG_OUTPUT_UNKNOWN":         0,
+	"ANALOG_OUTPUT_NONE":            1,
+	"ANALOG_OUTPUT_SUPPORTED":       2,
+	"ANALOG_OUTPUT_SUPPORTS_CGMS_A": 3,
+}
+
+func (x ClientIdentification_ClientCapabilities_AnalogOutputCapabilities) Enum() *ClientIdentification_ClientCapabilities_AnalogOutputCapabilities {
+	p := new(ClientIdentification_ClientCapabilities_AnalogOutputCapabilities)
+	*p = x
+	return p
+}
+func (x ClientIdentification_ClientCapabilities_AnalogOutputCapabilities) String() string {
+	return proto1.EnumName(ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_name, int32(x))
+}
+func (x *ClientIdentification_ClientCapabilities_AnalogOutputCapabilities) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_value, data, "ClientIdentification_ClientCapabilities_AnalogOutputCapabilities")
+	if err != nil {
+		return err
+	}
+	*x = ClientIdentification_ClientCapabilities_AnalogOutputCapabilities(value)
+	return nil
+}
+func (ClientIdentification_ClientCapabilities_AnalogOutputCapabilities) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 1, 1}
+}
+
+type WidevinePsshData_Type int32
+
+const (
+	WidevinePsshData_SINGLE       WidevinePsshData_Type = 0
+	WidevinePsshData_ENTITLEMENT  WidevinePsshData_Type = 1
+	WidevinePsshData_ENTITLED_KEY WidevinePsshData_Type = 2
+)
+
+var WidevinePsshData_Type_name = map[int32]string{
+	0: "SINGLE",
+	1: "ENTITLEMENT",
+	2: "ENTITLED_KEY",
+}
+var WidevinePsshData_Type_value = map[string]int32{
+	"SINGLE":       0,
+	"ENTITLEMENT":  1,
+	"ENTITLED_KEY": 2,
+}
+
+func (x WidevinePsshData_Type) Enum() *WidevinePsshData_Type {
+	p := new(WidevinePsshData_Type)
+	*p = x
+	return p
+}
+func (x WidevinePsshData_Type) String() string {
+	return proto1.EnumName(WidevinePsshData_Type_name, int32(x))
+}
+func (x *WidevinePsshData_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(WidevinePsshData_Type_value, data, "WidevinePsshData_Type")
+	if err != nil {
+		return err
+	}
+	*x = WidevinePsshData_Type(value)
+	return nil
+}
+func (WidevinePsshData_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
+
+type WidevinePsshData_Algorithm int32
+
+const (
+	WidevinePsshData_UNENCRYPTED WidevinePsshData_Algorithm = 0
+	WidevinePsshData_AESCTR      WidevinePsshData_Algorithm = 1
+)
+
+var WidevinePsshData_Algorithm_name = map[int32]string{
+	0: "UNENCRYPTED",
+	1: "AESCTR",
+}
+var WidevinePsshData_Algorithm_value = map[string]int32{
+	"UNENCRYPTED": 0,
+	"AESCTR":      1,
+}
+
+func (x WidevinePsshData_Algorithm) Enum() *WidevinePsshData_Algorithm {
+	p := new(WidevinePsshData_Algorithm)
+	*p = x
+	return p
+}
+func (x WidevinePsshData_Algorithm) String() string {
+	return proto1.EnumName(WidevinePsshData_Algorithm_name, int32(x))
+}
+func (x *WidevinePsshData_Algorithm) UnmarshalJSON(data []byte) error {
+	value, err := proto1.UnmarshalJSONEnum(WidevinePsshData_Algorithm_value, data, "WidevinePsshData_Algorithm")
+	if err != nil {
+		return err
+	}
+	*x = WidevinePsshData_Algorithm(value)
+	return nil
+}
+func (WidevinePsshData_Algorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{5, 1}
+}
+
+type SignedMessage struct {
+	Type             *SignedMessage_MessageType `protobuf:"varint,1,opt,name=type,enum=proto.SignedMessage_MessageType" json:"type,omitempty"`
+	Msg              []byte                     `protobuf:"bytes,2,opt,name=msg" json:"msg,omitempty"`
+	Signature        []byte                     `protobuf:"bytes,3,opt,name=signature" json:"signature,omitempty"`
+	SessionKey       []byte                     `protobuf:"bytes,4,opt,name=session_key,json=sessionKey" json:"session_key,omitempty"`
+	XXX_unrecognized []byte                     `json:"-"`
+}
+
+func (m *SignedMessage) Reset()                    { *m = SignedMessage{} }
+func (m *SignedMessage) String() string            { return proto1.CompactTextString(m) }
+func (*SignedMessage) ProtoMessage()               {}
+func (*SignedMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *SignedMessage) GetType() SignedMessage_MessageType {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return SignedMessage_LICENSE_REQUEST
+}
+
+func (m *SignedMessage) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *SignedMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedMessage) GetSessionKey() []byte {
+	if m != nil {
+		return m.SessionKey
+	}
+	return nil
+}
+
+type LicenseIdentification struct {
+	RequestId            []byte       `protobuf:"bytes,1,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	SessionId            []byte       `protobuf:"bytes,2,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	PurchaseId           []byte       `protobuf:"bytes,3,opt,name=purchase_id,json=purchaseId" json:"purchase_id,omitempty"`
+	Type                 *LicenseType `protobuf:"varint,4,opt,name=type,enum=proto.LicenseType" json:"type,omitempty"`
+	Version              *int32       `protobuf:"varint,5,opt,name=version" json:"version,omitempty"`
+	ProviderSessionToken []byte       `protobuf:"bytes,6,opt,name=provider_session_token,json=providerSessionToken" json:"provider_session_token,omitempty"`
+	XXX_unrecognized     []byte       `json:"-"`
+}
+
+func (m *LicenseIdentification) Reset()                    { *m = LicenseIdentification{} }
+func (m *LicenseIdentification) String() string            { return proto1.CompactTextString(m) }
+func (*LicenseIdentification) ProtoMessage()               {}
+func (*LicenseIdentification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *LicenseIdentification) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+func (m *LicenseIdentification) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *LicenseIdentification) GetPurchaseId() []byte {
+	if m != nil {
+		return m.PurchaseId
+	}
+	return nil
+}
+
+func (m *LicenseIdentification) GetType() LicenseType {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return LicenseType_STREAMING
+}
+
+func (m *LicenseIdentification) GetVersion() int32 {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return 0
+}
+
+func (m *LicenseIdentification) GetProviderSessionToken() []byte {
+	if m != nil {
+		return m.ProviderSessionToken
+	}
+	return nil
+}
+
+type LicenseRequest struct {
+	// Not set when the client identification is encrypted.
+	ClientId  *ClientIdentification                 `protobuf:"bytes,1,opt,name=client_id,json=clientId" json:"client_id,omitempty"`
+	ContentId *LicenseRequest_ContentIdentification `protobuf:"bytes,2,opt,name=content_id,json=contentId" json:"content_id,omitempty"`
+	Type      *LicenseRequest_RequestType           `protobuf:"varint,3,opt,name=type,enum=proto.LicenseRequest_RequestType" json:"type,omitempty"`
+	// Time of the request in seconds since the Unix epoch.
+	RequestTime               *int64           `protobuf:"varint,4,opt,name=request_time,json=requestTime" json:"request_time,omitempty"`
+	KeyControlNonceDeprecated []byte           `protobuf:"bytes,5,opt,name=key_control_nonce_deprecated,json=keyControlNonceDeprecated" json:"key_control_nonce_deprecated,omitempty"`
+	ProtocolVersion           *ProtocolVersion `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,enum=proto.ProtocolVersion,def=20" json:"protocol_version,omitempty"`
+	KeyControlNonce           *uint32          `protobuf:"varint,7,opt,name=key_control_nonce,json=keyControlNonce" json:"key_control_nonce,omitempty"`
+	// Set instead of client_id in privacy mode.
+	EncryptedClientId *EncryptedClientIdentification `protobuf:"bytes,8,opt,name=encrypted_client_id,json=encryptedClientId" json:"encrypted_client_id,omitempty"`
+	XXX_unrecognized  []byte                         `json:"-"`
+}
+
+func (m *LicenseRequest) Reset()                    { *m = LicenseRequest{} }
+func (m *LicenseRequest) String() string            { return proto1.CompactTextString(m) }
+func (*LicenseRequest) ProtoMessage()               {}
+func (*LicenseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+const Default_LicenseRequest_ProtocolVersion ProtocolVersion = ProtocolVersion_VERSION_2_0
+
+func (m *LicenseRequest) GetClientId() *ClientIdentification {
+	if m != nil {
+		return m.ClientId
+	}
+	return nil
+}
+
+func (m *LicenseRequest) GetContentId() *LicenseRequest_ContentIdentification {
+	if m != nil {
+		return m.ContentId
+	}
+	return nil
+}
+
+func (m *LicenseRequest) GetType() LicenseRequest_RequestType {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return LicenseRequest_NEW
+}
+
+func (m *LicenseRequest) GetRequestTime() int64 {
+	if m != nil && m.RequestTime != nil {
+		return *m.RequestTime
+	}
+	return 0
+}
+
+func (m *LicenseRequest) GetKeyControlNonceDeprecated() []byte {
+	if m != nil {
+		return m.KeyControlNonceDeprecated
+	}
+	return nil
+}
+
+func (m *LicenseRequest) GetProtocolVersion() ProtocolVersion {
+	if m != nil && m.ProtocolVersion != nil {
+		return *m.ProtocolVersion
+	}
+	return Default_LicenseRequest_ProtocolVersion
+}
+
+func (m *LicenseRequest) GetKeyControlNonce() uint32 {
+	if m != nil && m.KeyControlNonce != nil {
+		return *m.KeyControlNonce
+	}
+	return 0
+}
+
+func (m *LicenseRequest) GetEncryptedClientId() *EncryptedClientIdentification {
+	if m != nil {
+		return m.EncryptedClientId
+	}
+	return nil
+}
+
+type LicenseRequest_ContentIdentification struct {
+	// Exactly one of these is set.
+	WidevinePsshData *LicenseRequest_ContentIdentification_CencDeprecated  `protobuf:"bytes,1,opt,name=widevine_pssh_data,json=widevinePsshData" json:"widevine_pssh_data,omitempty"`
+	WebmKeyId        *LicenseRequest_ContentIdentification_WebmDeprecated  `protobuf:"bytes,2,opt,name=webm_key_id,json=webmKeyId" json:"webm_key_id,omitempty"`
+	ExistingLicense  *LicenseRequest_ContentIdentification_ExistingLicense `protobuf:"bytes,3,opt,name=existing_license,json=existingLicense" json:"existing_license,omitempty"`
+	InitData         *LicenseRequest_ContentIdentification_InitData        `protobuf:"bytes,4,opt,name=init_data,json=initData" json:"init_data,omitempty"`
+	XXX_unrecognized []byte                                                `json:"-"`
+}
+
+func (m *LicenseRequest_ContentIdentification) Reset()         { *m = LicenseRequest_ContentIdentification{} }
+func (m *LicenseRequest_ContentIdentification) String() string { return proto1.CompactTextString(m) }
+func (*LicenseRequest_ContentIdentification) ProtoMessage()    {}
+func (*LicenseRequest_ContentIdentification) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0}
+}
+
+func (m *LicenseRequest_ContentIdentification) GetWidevinePsshData() *LicenseRequest_ContentIdentification_CencDeprecated {
+	if m != nil {
+		return m.WidevinePsshData
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification) GetWebmKeyId() *LicenseRequest_ContentIdentification_WebmDeprecated {
+	if m != nil {
+		return m.WebmKeyId
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification) GetExistingLicense() *LicenseRequest_ContentIdentification_ExistingLicense {
+	if m != nil {
+		return m.ExistingLicense
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification) GetInitData() *LicenseRequest_ContentIdentification_InitData {
+	if m != nil {
+		return m.InitData
+	}
+	return nil
+}
+
+type LicenseRequest_ContentIdentification_CencDeprecated struct {
+	// One or more WidevinePsshData.
+	Pssh             [][]byte     `protobuf:"bytes,1,rep,name=pssh" json:"pssh,omitempty"`
+	LicenseType      *LicenseType `protobuf:"varint,2,opt,name=license_type,json=licenseType,enum=proto.LicenseType" json:"license_type,omitempty"`
+	RequestId        []byte       `protobuf:"bytes,3,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	XXX_unrecognized []byte       `json:"-"`
+}
+
+func (m *LicenseRequest_ContentIdentification_CencDeprecated) Reset() {
+	*m = LicenseRequest_ContentIdentification_CencDeprecated{}
+}
+func (m *LicenseRequest_ContentIdentification_CencDeprecated) String() string {
+	return proto1.CompactTextString(m)
+}
+func (*LicenseRequest_ContentIdentification_CencDeprecated) ProtoMessage() {}
+func (*LicenseRequest_ContentIdentification_CencDeprecated) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0, 0}
+}
+
+func (m *LicenseRequest_ContentIdentification_CencDeprecated) GetPssh() [][]byte {
+	if m != nil {
+		return m.Pssh
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification_CencDeprecated) GetLicenseType() LicenseType {
+	if m != nil && m.LicenseType != nil {
+		return *m.LicenseType
+	}
+	return LicenseType_STREAMING
+}
+
+func (m *LicenseRequest_ContentIdentification_CencDeprecated) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+type LicenseRequest_ContentIdentification_WebmDeprecated struct {
+	Header           []byte       `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	LicenseType      *LicenseType `protobuf:"varint,2,opt,name=license_type,json=licenseType,enum=proto.LicenseType" json:"license_type,omitempty"`
+	RequestId        []byte       `protobuf:"bytes,3,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	XXX_unrecognized []byte       `json:"-"`
+}
+
+func (m *LicenseRequest_ContentIdentification_WebmDeprecated) Reset() {
+	*m = LicenseRequest_ContentIdentification_WebmDeprecated{}
+}
+func (m *LicenseRequest_ContentIdentification_WebmDeprecated) String() string {
+	return proto1.CompactTextString(m)
+}
+func (*LicenseRequest_ContentIdentification_WebmDeprecated) ProtoMessage() {}
+func (*LicenseRequest_ContentIdentification_WebmDeprecated) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0, 1}
+}
+
+func (m *LicenseRequest_ContentIdentification_WebmDeprecated) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification_WebmDeprecated) GetLicenseType() LicenseType {
+	if m != nil && m.LicenseType != nil {
+		return *m.LicenseType
+	}
+	return LicenseType_STREAMING
+}
+
+func (m *LicenseRequest_ContentIdentification_WebmDeprecated) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+type LicenseRequest_ContentIdentification_ExistingLicense struct {
+	LicenseId              *LicenseIdentification `protobuf:"bytes,1,opt,name=license_id,json=licenseId" json:"license_id,omitempty"`
+	SecondsSinceStarted    *int64                 `protobuf:"varint,2,opt,name=seconds_since_started,json=secondsSinceStarted" json:"seconds_since_started,omitempty"`
+	SecondsSinceLastPlayed *int64                 `protobuf:"varint,3,opt,name=seconds_since_last_played,json=secondsSinceLastPlayed" json:"seconds_since_last_played,omitempty"`
+	SessionUsageTableEntry []byte                 `protobuf:"bytes,4,opt,name=session_usage_table_entry,json=sessionUsageTableEntry" json:"session_usage_table_entry,omitempty"`
+	XXX_unrecognized       []byte                 `json:"-"`
+}
+
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) Reset() {
+	*m = LicenseRequest_ContentIdentification_ExistingLicense{}
+}
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) String() string {
+	return proto1.CompactTextString(m)
+}
+func (*LicenseRequest_ContentIdentification_ExistingLicense) ProtoMessage() {}
+func (*LicenseRequest_ContentIdentification_ExistingLicense) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0, 2}
+}
+
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) GetLicenseId() *LicenseIdentification {
+	if m != nil {
+		return m.LicenseId
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) GetSecondsSinceStarted() int64 {
+	if m != nil && m.SecondsSinceStarted != nil {
+		return *m.SecondsSinceStarted
+	}
+	return 0
+}
+
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) GetSecondsSinceLastPlayed() int64 {
+	if m != nil && m.SecondsSinceLastPlayed != nil {
+		return *m.SecondsSinceLastPlayed
+	}
+	return 0
+}
+
+func (m *LicenseRequest_ContentIdentification_ExistingLicense) GetSessionUsageTableEntry() []byte {
+	if m != nil {
+		return m.SessionUsageTableEntry
+	}
+	return nil
+}
+
+type LicenseRequest_ContentIdentification_InitData struct {
+	InitDataType *LicenseRequest_ContentIdentification_InitData_InitDataType `protobuf:"varint,1,opt,name=init_data_type,json=initDataType,enum=proto.LicenseRequest_ContentIdentification_InitData_InitDataType,def=1" json:"init_data_type,omitempty"`
+	// A PSSH box for CENC init data.
+	InitData         []byte       `protobuf:"bytes,2,opt,name=init_data,json=initData" json:"init_data,omitempty"`
+	LicenseType      *LicenseType `protobuf:"varint,3,opt,name=license_type,json=licenseType,enum=proto.LicenseType" json:"license_type,omitempty"`
+	RequestId        []byte       `protobuf:"bytes,4,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	XXX_unrecognized []byte       `json:"-"`
+}
+
+func (m *LicenseRequest_ContentIdentification_InitData) Reset() {
+	*m = LicenseRequest_ContentIdentification_InitData{}
+}
+func (m *LicenseRequest_ContentIdentification_InitData) String() string {
+	return proto1.CompactTextString(m)
+}
+func (*LicenseRequest_ContentIdentification_InitData) ProtoMessage() {}
+func (*LicenseRequest_ContentIdentification_InitData) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2, 0, 3}
+}
+
+const Default_LicenseRequest_ContentIdentification_InitData_InitDataType LicenseRequest_ContentIdentification_InitData_InitDataType = LicenseRequest_ContentIdentification_InitData_CENC
+
+func (m *LicenseRequest_ContentIdentification_InitData) GetInitDataType() LicenseRequest_ContentIdentification_InitData_InitDataType {
+	if m != nil && m.InitDataType != nil {
+		return *m.InitDataType
+	}
+	return Default_LicenseRequest_ContentIdentification_InitData_InitDataType
+}
+
+func (m *LicenseRequest_ContentIdentification_InitData) GetInitData() []byte {
+	if m != nil {
+		return m.InitData
+	}
+	return nil
+}
+
+func (m *LicenseRequest_ContentIdentification_InitData) GetLicenseType() LicenseType {
+	if m != nil && m.LicenseType != nil {
+		return *m.LicenseType
+	}
+	return LicenseType_STREAMING
+}
+
+func (m *LicenseRequest_ContentIdentification_InitData) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+type ClientIdentification struct {
+	Type                *ClientIdentification_TokenType          `protobuf:"varint,1,opt,name=type,enum=proto.ClientIdentification_TokenType,def=0" json:"type,omitempty"`
+	Token               []byte                                   `protobuf:"bytes,2,opt,name=token" json:"token,omitempty"`
+	ClientInfo          []*ClientIdentification_NameValue        `protobuf:"bytes,3,rep,name=client_info,json=clientInfo" json:"client_info,omitempty"`
+	ProviderClientToken []byte                                   `protobuf:"bytes,4,opt,name=provider_client_token,json=providerClientToken" json:"provider_client_token,omitempty"`
+	LicenseCounter      *uint32                                  `protobuf:"varint,5,opt,name=license_counter,json=licenseCounter" json:"license_counter,omitempty"`
+	ClientCapabilities  *ClientIdentification_ClientCapabilities `protobuf:"bytes,6,opt,name=client_capabilities,json=clientCapabilities" json:"client_capabilities,omitempty"`
+	VmpData             []byte                                   `protobuf:"bytes,7,opt,name=vmp_data,json=vmpData" json:"vmp_data,omitempty"`
+	XXX_unrecognized    []byte                                   `json:"-"`
+}
+
+func (m *ClientIdentification) Reset()                    { *m = ClientIdentification{} }
+func (m *ClientIdentification) String() string            { return proto1.CompactTextString(m) }
+func (*ClientIdentification) ProtoMessage()               {}
+func (*ClientIdentification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+const Default_ClientIdentification_Type ClientIdentification_TokenType = ClientIdentification_KEYBOX
+
+func (m *ClientIdentification) GetType() ClientIdentification_TokenType {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return Default_ClientIdentification_Type
+}
+
+func (m *ClientIdentification) GetToken() []byte {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+func (m *ClientIdentification) GetClientInfo() []*ClientIdentification_NameValue {
+	if m != nil {
+		return m.ClientInfo
+	}
+	return nil
+}
+
+func (m *ClientIdentification) GetProviderClientToken() []byte {
+	if m != nil {
+		return m.ProviderClientToken
+	}
+	return nil
+}
+
+func (m *ClientIdentification) GetLicenseCounter() uint32 {
+	if m != nil && m.LicenseCounter != nil {
+		return *m.LicenseCounter
+	}
+	return 0
+}
+
+func (m *ClientIdentification) GetClientCapabilities() *ClientIdentification_ClientCapabilities {
+	if m != nil {
+		return m.ClientCapabilities
+	}
+	return nil
+}
+
+func (m *ClientIdentification) GetVmpData() []byte {
+	if m != nil {
+		return m.VmpData
+	}
+	return nil
+}
+
+type ClientIdentification_NameValue struct {
+	Name             *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value            *string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *ClientIdentification_NameValue) Reset()         { *m = ClientIdentification_NameValue{} }
+func (m *ClientIdentification_NameValue) String() string { return proto1.CompactTextString(m) }
+func (*ClientIdentification_NameValue) ProtoMessage()    {}
+func (*ClientIdentification_NameValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 0}
+}
+
+func (m *ClientIdentification_NameValue) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ClientIdentification_NameValue) GetValue() string {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return ""
+}
+
+// Capabilities the client reports to the license server.
+type ClientIdentification_ClientCapabilities struct {
+	ClientToken                *bool                                                             `protobuf:"varint,1,opt,name=client_token,json=clientToken,def=0" json:"client_token,omitempty"`
+	SessionToken               *bool                                                             `protobuf:"varint,2,opt,name=session_token,json=sessionToken,def=0" json:"session_token,omitempty"`
+	VideoResolutionConstraints *bool                                                             `protobuf:"varint,3,opt,name=video_resolution_constraints,json=videoResolutionConstraints,def=0" json:"video_resolution_constraints,omitempty"`
+	MaxHdcpVersion             *ClientIdentification_ClientCapabilities_HdcpVersion              `protobuf:"varint,4,opt,name=max_hdcp_version,json=maxHdcpVersion,enum=proto.ClientIdentification_ClientCapabilities_HdcpVersion,def=0" json:"max_hdcp_version,omitempty"`
+	OemCryptoApiVersion        *uint32                                                           `protobuf:"varint,5,opt,name=oem_crypto_api_version,json=oemCryptoApiVersion" json:"oem_crypto_api_version,omitempty"`
+	AntiRollbackUsageTable     *bool                                                             `protobuf:"varint,6,opt,name=anti_rollback_usage_table,json=antiRollbackUsageTable,def=0" json:"anti_rollback_usage_table,omitempty"`
+	SrmVersion                 *uint32                                                           `protobuf:"varint,7,opt,name=srm_version,json=srmVersion" json:"srm_version,omitempty"`
+	CanUpdateSrm               *bool                                                             `protobuf:"varint,8,opt,name=can_update_srm,json=canUpdateSrm,def=0" json:"can_update_srm,omitempty"`
+	AnalogOutputCapabilities   *ClientIdentification_ClientCapabilities_AnalogOutputCapabilities `protobuf:"varint,10,opt,name=analog_output_capabilities,json=analogOutputCapabilities,enum=proto.ClientIdentification_ClientCapabilities_AnalogOutputCapabilities,def=0" json:"analog_output_capabilities,omitempty"`
+	CanDisableAnalogOutput     *bool                                                             `protobuf:"varint,11,opt,name=can_disable_analog_output,json=canDisableAnalogOutput,def=0" json:"can_disable_analog_output,omitempty"`
+	ResourceRatingTier         *uint32                                                           `protobuf:"varint,12,opt,name=resource_rating_tier,json=resourceRatingTier,def=0" json:"resource_rating_tier,omitempty"`
+	XXX_unrecognized           []byte                                                            `json:"-"`
+}
+
+func (m *ClientIdentification_ClientCapabilities) Reset() {
+	*m = ClientIdentification_ClientCapabilities{}
+}
+func (m *ClientIdentification_ClientCapabilities) String() string { return proto1.CompactTextString(m) }
+func (*ClientIdentification_ClientCapabilities) ProtoMessage()    {}
+func (*ClientIdentification_ClientCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 1}
+}
+
+const Default_ClientIdentification_ClientCapabilities_ClientToken bool = false
+const Default_ClientIdentification_ClientCapabilities_SessionToken bool = false
+const Default_ClientIdentification_ClientCapabilities_VideoResolutionConstraints bool = false
+const Default_ClientIdentification_ClientCapabilities_MaxHdcpVersion ClientIdentification_ClientCapabilities_HdcpVersion = ClientIdentification_ClientCapabilities_HDCP_NONE
+const Default_ClientIdentification_ClientCapabilities_AntiRollbackUsageTable bool = false
+const Default_ClientIdentification_ClientCapabilities_CanUpdateSrm bool = false
+const Default_ClientIdentification_ClientCapabilities_AnalogOutputCapabilities ClientIdentification_ClientCapabilities_AnalogOutputCapabilities = ClientIdentification_ClientCapabilities_ANALOG_OUTPUT_UNKNOWN
+const Default_ClientIdentification_ClientCapabilities_CanDisableAnalogOutput bool = false
+const Default_ClientIdentification_ClientCapabilities_ResourceRatingTier uint32 = 0
+
+func (m *ClientIdentification_ClientCapabilities) GetClientToken() bool {
+	if m != nil && m.ClientToken != nil {
+		return *m.ClientToken
+	}
+	return Default_ClientIdentification_ClientCapabilities_ClientToken
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetSessionToken() bool {
+	if m != nil && m.SessionToken != nil {
+		return *m.SessionToken
+	}
+	return Default_ClientIdentification_ClientCapabilities_SessionToken
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetVideoResolutionConstraints() bool {
+	if m != nil && m.VideoResolutionConstraints != nil {
+		return *m.VideoResolutionConstraints
+	}
+	return Default_ClientIdentification_ClientCapabilities_VideoResolutionConstraints
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetMaxHdcpVersion() ClientIdentification_ClientCapabilities_HdcpVersion {
+	if m != nil && m.MaxHdcpVersion != nil {
+		return *m.MaxHdcpVersion
+	}
+	return Default_ClientIdentification_ClientCapabilities_MaxHdcpVersion
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetOemCryptoApiVersion() uint32 {
+	if m != nil && m.OemCryptoApiVersion != nil {
+		return *m.OemCryptoApiVersion
+	}
+	return 0
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetAntiRollbackUsageTable() bool {
+	if m != nil && m.AntiRollbackUsageTable != nil {
+		return *m.AntiRollbackUsageTable
+	}
+	return Default_ClientIdentification_ClientCapabilities_AntiRollbackUsageTable
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetSrmVersion() uint32 {
+	if m != nil && m.SrmVersion != nil {
+		return *m.SrmVersion
+	}
+	return 0
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetCanUpdateSrm() bool {
+	if m != nil && m.CanUpdateSrm != nil {
+		return *m.CanUpdateSrm
+	}
+	return Default_ClientIdentification_ClientCapabilities_CanUpdateSrm
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetAnalogOutputCapabilities() ClientIdentification_ClientCapabilities_AnalogOutputCapabilities {
+	if m != nil && m.AnalogOutputCapabilities != nil {
+		return *m.AnalogOutputCapabilities
+	}
+	return Default_ClientIdentification_ClientCapabilities_AnalogOutputCapabilities
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetCanDisableAnalogOutput() bool {
+	if m != nil && m.CanDisableAnalogOutput != nil {
+		return *m.CanDisableAnalogOutput
+	}
+	return Default_ClientIdentification_ClientCapabilities_CanDisableAnalogOutput
+}
+
+func (m *ClientIdentification_ClientCapabilities) GetResourceRatingTier() uint32 {
+	if m != nil && m.ResourceRatingTier != nil {
+		return *m.ResourceRatingTier
+	}
+	return Default_ClientIdentification_ClientCapabilities_ResourceRatingTier
+}
+
+type EncryptedClientIdentification struct {
+	ProviderId                     *string `protobuf:"bytes,1,opt,name=provider_id,json=providerId" json:"provider_id,omitempty"`
+	ServiceCertificateSerialNumber []byte  `protobuf:"bytes,2,opt,name=service_certificate_serial_number,json=serviceCertificateSerialNumber" json:"service_certificate_serial_number,omitempty"`
+	// Serialized ClientIdentification, encrypted with the privacy key.
+	EncryptedClientId   []byte `protobuf:"bytes,3,opt,name=encrypted_client_id,json=encryptedClientId" json:"encrypted_client_id,omitempty"`
+	EncryptedClientIdIv []byte `protobuf:"bytes,4,opt,name=encrypted_client_id_iv,json=encryptedClientIdIv" json:"encrypted_client_id_iv,omitempty"`
+	// Privacy key, encrypted with the service certificate public key.
+	EncryptedPrivacyKey []byte `protobuf:"bytes,5,opt,name=encrypted_privacy_key,json=encryptedPrivacyKey" json:"encrypted_privacy_key,omitempty"`
+	XXX_unrecognized    []byte `json:"-"`
+}
+
+func (m *EncryptedClientIdentification) Reset()                    { *m = EncryptedClientIdentification{} }
+func (m *EncryptedClientIdentification) String() string            { return proto1.CompactTextString(m) }
+func (*EncryptedClientIdentification) ProtoMessage()               {}
+func (*EncryptedClientIdentification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *EncryptedClientIdentification) GetProviderId() string {
+	if m != nil && m.ProviderId != nil {
+		return *m.ProviderId
+	}
+	return ""
+}
+
+func (m *EncryptedClientIdentification) GetServiceCertificateSerialNumber() []byte {
+	if m != nil {
+		return m.ServiceCertificateSerialNumber
+	}
+	return nil
+}
+
+func (m *EncryptedClientIdentification) GetEncryptedClientId() []byte {
+	if m != nil {
+		return m.EncryptedClientId
+	}
+	return nil
+}
+
+func (m *EncryptedClientIdentification) GetEncryptedClientIdIv() []byte {
+	if m != nil {
+		return m.EncryptedClientIdIv
+	}
+	return nil
+}
+
+func (m *EncryptedClientIdentification) GetEncryptedPrivacyKey() []byte {
+	if m != nil {
+		return m.EncryptedPrivacyKey
+	}
+	return nil
+}
+
+// Data of a Widevine PSSH box. Supersedes WidevineCencHeader, with which it
+// is wire compatible.
+type WidevinePsshData struct {
+	Algorithm         *WidevinePsshData_Algorithm `protobuf:"varint,1,opt,name=algorithm,enum=proto.WidevinePsshData_Algorithm" json:"algorithm,omitempty"`
+	KeyIds            [][]byte                    `protobuf:"bytes,2,rep,name=key_ids,json=keyIds" json:"key_ids,omitempty"`
+	Provider          *string                     `protobuf:"bytes,3,opt,name=provider" json:"provider,omitempty"`
+	ContentId         []byte                      `protobuf:"bytes,4,opt,name=content_id,json=contentId" json:"content_id,omitempty"`
+	TrackType         *string                     `protobuf:"bytes,5,opt,name=track_type,json=trackType" json:"track_type,omitempty"`
+	Policy            *string                     `protobuf:"bytes,6,opt,name=policy" json:"policy,omitempty"`
+	CryptoPeriodIndex *uint32                     `protobuf:"varint,7,opt,name=crypto_period_index,json=cryptoPeriodIndex" json:"crypto_period_index,omitempty"`
+	GroupedLicense    []byte                      `protobuf:"bytes,8,opt,name=grouped_license,json=groupedLicense" json:"grouped_license,omitempty"`
+	// 4CC of the protection scheme, e.g. 'cenc' or 'cbcs'.
+	ProtectionScheme    *uint32                `protobuf:"varint,9,opt,name=protection_scheme,json=protectionScheme" json:"protection_scheme,omitempty"`
+	CryptoPeriodSeconds *uint32                `protobuf:"varint,10,opt,name=crypto_period_seconds,json=cryptoPeriodSeconds" json:"crypto_period_seconds,omitempty"`
+	Type                *WidevinePsshData_Type `protobuf:"varint,11,opt,name=type,enum=proto.WidevinePsshData_Type,def=0" json:"type,omitempty"`
+	KeySequence         *uint32                `protobuf:"varint,12,opt,name=key_sequence,json=keySequence" json:"key_sequence,omitempty"`
+	GroupIds            [][]byte               `protobuf:"bytes,13,rep,name=group_ids,json=groupIds" json:"group_ids,omitempty"`
+	VideoFeature        *string                `protobuf:"bytes,15,opt,name=video_feature,json=videoFeature" json:"video_feature,omitempty"`
+	XXX_unrecognized    []byte                 `json:"-"`
+}
+
+func (m *WidevinePsshData) Reset()                    { *m = WidevinePsshData{} }
+func (m *WidevinePsshData) String() string            { return proto1.CompactTextString(m) }
+func (*WidevinePsshData) ProtoMessage()               {}
+func (*WidevinePsshData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+const Default_WidevinePsshData_Type WidevinePsshData_Type = WidevinePsshData_SINGLE
+
+func (m *WidevinePsshData) GetAlgorithm() WidevinePsshData_Algorithm {
+	if m != nil && m.Algorithm != nil {
+		return *m.Algorithm
+	}
+	return WidevinePsshData_UNENCRYPTED
+}
+
+func (m *WidevinePsshData) GetKeyIds() [][]byte {
+	if m != nil {
+		return m.KeyIds
+	}
+	return nil
+}
+
+func (m *WidevinePsshData) GetProvider() string {
+	if m != nil && m.Provider != nil {
+		return *m.Provider
+	}
+	return ""
+}
+
+func (m *WidevinePsshData) GetContentId() []byte {
+	if m != nil {
+		return m.ContentId
+	}
+	return nil
+}
+
+func (m *WidevinePsshData) GetTrackType() string {
+	if m != nil && m.TrackType != nil {
+		return *m.TrackType
+	}
+	return ""
+}
+
+func (m *WidevinePsshData) GetPolicy() string {
+	if m != nil && m.Policy != nil {
+		return *m.Policy
+	}
+	return ""
+}
+
+func (m *WidevinePsshData) GetCryptoPeriodIndex() uint32 {
+	if m != nil && m.CryptoPeriodIndex != nil {
+		return *m.CryptoPeriodIndex
+	}
+	return 0
+}
+
+func (m *WidevinePsshData) GetGroupedLicense() []byte {
+	if m != nil {
+		return m.GroupedLicense
+	}
+	return nil
+}
+
+func (m *WidevinePsshData) GetProtectionScheme() uint32 {
+	if m != nil && m.ProtectionScheme != nil {
+		return *m.ProtectionScheme
+	}
+	return 0
+}
+
+func (m *WidevinePsshData) GetCryptoPeriodSeconds() uint32 {
+	if m != nil && m.CryptoPeriodSeconds != nil {
+		return *m.CryptoPeriodSeconds
+	}
+	return 0
+}
+
+func (m *WidevinePsshData) GetType() WidevinePsshData_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return Default_WidevinePsshData_Type
+}
+
+func (m *WidevinePsshData) GetKeySequence() uint32 {
+	if m != nil && m.KeySequence != nil {
+		return *m.KeySequence
+	}
+	return 0
+}
+
+func (m *WidevinePsshData) GetGroupIds() [][]byte {
+	if m != nil {
+		return m.GroupIds
+	}
+	return nil
+}
+
+func (m *WidevinePsshData) GetVideoFeature() string {
+	if m != nil && m.VideoFeature != nil {
+		return *m.VideoFeature
+	}
+	return ""
+}
+
+func init() {
+	proto1.RegisterType((*SignedMessage)(nil), "proto.SignedMessage")
+	proto1.RegisterType((*LicenseIdentification)(nil), "proto.LicenseIdentification")
+	proto1.RegisterType((*LicenseRequest)(nil), "proto.LicenseRequest")
+	proto1.RegisterType((*LicenseRequest_ContentIdentification)(nil), "proto.LicenseRequest.ContentIdentification")
+	proto1.RegisterType((*LicenseRequest_ContentIdentification_CencDeprecated)(nil), "proto.LicenseRequest.ContentIdentification.CencDeprecated")
+	proto1.RegisterType((*LicenseRequest_ContentIdentification_WebmDeprecated)(nil), "proto.LicenseRequest.ContentIdentification.WebmDeprecated")
+	proto1.RegisterType((*LicenseRequest_ContentIdentification_ExistingLicense)(nil), "proto.LicenseRequest.ContentIdentification.ExistingLicense")
+	proto1.RegisterType((*LicenseRequest_ContentIdentification_InitData)(nil), "proto.LicenseRequest.ContentIdentification.InitData")
+	proto1.RegisterType((*ClientIdentification)(nil), "proto.ClientIdentification")
+	proto1.RegisterType((*ClientIdentification_NameValue)(nil), "proto.ClientIdentification.NameValue")
+	proto1.RegisterType((*ClientIdentification_ClientCapabilities)(nil), "proto.ClientIdentification.ClientCapabilities")
+	proto1.RegisterType((*EncryptedClientIdentification)(nil), "proto.EncryptedClientIdentification")
+	proto1.RegisterType((*WidevinePsshData)(nil), "proto.WidevinePsshData")
+	proto1.RegisterEnum("proto.LicenseType", LicenseType_name, LicenseType_value)
+	proto1.RegisterEnum("proto.ProtocolVersion", ProtocolVersion_name, ProtocolVersion_value)
+	proto1.RegisterEnum("proto.SignedMessage_MessageType", SignedMessage_MessageType_name, SignedMessage_MessageType_value)
+	proto1.RegisterEnum("proto.LicenseRequest_RequestType", LicenseRequest_RequestType_name, LicenseRequest_RequestType_value)
+	proto1.RegisterEnum("proto.LicenseRequest_ContentIdentification_InitData_InitDataType", LicenseRequest_ContentIdentification_InitData_InitDataType_name, LicenseRequest_ContentIdentification_InitData_InitDataType_value)
+	proto1.RegisterEnum("proto.ClientIdentification_TokenType", ClientIdentification_TokenType_name, ClientIdentification_TokenType_value)
+	proto1.RegisterEnum("proto.ClientIdentification_ClientCapabilities_HdcpVersion", ClientIdentification_ClientCapabilities_HdcpVersion_name, ClientIdentification_ClientCapabilities_HdcpVersion_value)
+	proto1.RegisterEnum("proto.ClientIdentification_ClientCapabilities_AnalogOutputCapabilities", ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_name, ClientIdentification_ClientCapabilities_AnalogOutputCapabilities_value)
+	proto1.RegisterEnum("proto.WidevinePsshData_Type", WidevinePsshData_Type_name, WidevinePsshData_Type_value)
+	proto1.RegisterEnum("proto.WidevinePsshData_Algorithm", WidevinePsshData_Algorithm_name, WidevinePsshData_Algorithm_value)
+}
+
+func init() { proto1.RegisterFile("LicenseProtocol.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 2078 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x0f, 0x25, 0x7f, 0x48, 0x4f, 0xb2, 0xcd, 0x8c, 0x13, 0x2d, 0xe3, 0x24, 0xbb, 0x8e, 0xfa,
+	0x65, 0x24, 0x80, 0x91, 0x38, 0x09, 0x90, 0x7a, 0x0f, 0x5b, 0x45, 0x66, 0xbc, 0x6c, 0x6c, 0x4a,
+	0x3b, 0xa4, 0xed, 0xa6, 0x97, 0x01, 0x4d, 0x8e, 0xed, 0x81, 0xc5, 0x8f, 0x92, 0x94, 0x37, 0xea,
+	0xa1, 0xe8, 0x3f, 0xd0, 0xa2, 0xe8, 0xa9, 0x87, 0x9e, 0x8a, 0x02, 0xfd, 0x7b, 0x7a, 0xed, 0x1f,
+	0xd1, 0x73, 0x6f, 0x2d, 0xe6, 0x83, 0x12, 0x29, 0x3b, 0x01, 0xbc, 0x40, 0x4f, 0x9a, 0x79, 0xdf,
+	0xf3, 0x7b, 0xc3, 0xf7, 0xde, 0x08, 0xee, 0x1f, 0x30, 0x9f, 0x46, 0x19, 0x1d, 0xa6, 0x71, 0x1e,
+	0xfb, 0xf1, 0x68, 0x3b, 0xe1, 0x0b, 0xb4, 0x28, 0x7e, 0xba, 0xff, 0xa8, 0xc1, 0x8a, 0xc3, 0xce,
+	0x23, 0x1a, 0x1c, 0xd2, 0x2c, 0xf3, 0xce, 0x29, 0x7a, 0x05, 0x0b, 0xf9, 0x24, 0xa1, 0x86, 0xb6,
+	0xa9, 0x6d, 0xad, 0xee, 0x6c, 0x4a, 0xf1, 0xed, 0x8a, 0xcc, 0xb6, 0xfa, 0x75, 0x27, 0x09, 0xc5,
+	0x42, 0x1a, 0xe9, 0x50, 0x0f, 0xb3, 0x73, 0xa3, 0xb6, 0xa9, 0x6d, 0xb5, 0x31, 0x5f, 0xa2, 0x47,
+	0xd0, 0xcc, 0xd8, 0x79, 0xe4, 0xe5, 0xe3, 0x94, 0x1a, 0x75, 0x41, 0x9f, 0x11, 0xd0, 0x57, 0xd0,
+	0xca, 0x68, 0x96, 0xb1, 0x38, 0x22, 0x97, 0x74, 0x62, 0x2c, 0x08, 0x3e, 0x28, 0xd2, 0x7b, 0x3a,
+	0xe9, 0xfe, 0x51, 0x83, 0x56, 0xc9, 0x0d, 0x5a, 0x87, 0xb5, 0x03, 0xab, 0x6f, 0xda, 0x8e, 0x49,
+	0xb0, 0xf9, 0xdd, 0x91, 0xe9, 0xb8, 0xba, 0x86, 0x5a, 0xb0, 0xac, 0x88, 0x7a, 0x0d, 0x21, 0x58,
+	0x35, 0x31, 0x1e, 0x60, 0x82, 0x4d, 0x67, 0x38, 0xe0, 0xb4, 0x3a, 0xfa, 0x0a, 0x1e, 0x3a, 0x26,
+	0x3e, 0xb6, 0xfa, 0x26, 0xe9, 0x9b, 0xd8, 0xb5, 0xde, 0x59, 0xfd, 0x9e, 0x3b, 0xb3, 0xb0, 0x80,
+	0xbe, 0x80, 0xf5, 0x1b, 0x04, 0xf4, 0x45, 0xb4, 0x06, 0x2d, 0xe7, 0xe8, 0x2d, 0x29, 0xcc, 0x2f,
+	0x75, 0xff, 0xad, 0x4d, 0xa1, 0xb4, 0x02, 0x1a, 0xe5, 0xec, 0x8c, 0xf9, 0x5e, 0xce, 0xe2, 0x08,
+	0x3d, 0x06, 0x48, 0xe9, 0x6f, 0xc6, 0x34, 0xcb, 0x09, 0x0b, 0x04, 0x6e, 0x6d, 0xdc, 0x54, 0x14,
+	0x2b, 0xe0, 0xec, 0xe2, 0xa8, 0x2c, 0x50, 0x08, 0x35, 0x15, 0xc5, 0x0a, 0x38, 0x12, 0xc9, 0x38,
+	0xf5, 0x2f, 0xbc, 0x8c, 0x72, 0xbe, 0x44, 0x0a, 0x0a, 0x92, 0x15, 0xa0, 0x9f, 0xaa, 0x84, 0x2c,
+	0x88, 0x84, 0x20, 0x95, 0x10, 0x15, 0x4a, 0x29, 0x05, 0x06, 0x2c, 0x5f, 0xd1, 0x94, 0x5b, 0x35,
+	0x16, 0x37, 0xb5, 0xad, 0x45, 0x5c, 0x6c, 0xd1, 0x2b, 0xe8, 0x24, 0x69, 0x7c, 0xc5, 0x02, 0x9a,
+	0x92, 0x22, 0x94, 0x3c, 0xbe, 0xa4, 0x91, 0xb1, 0x24, 0xbc, 0xdd, 0x2b, 0xb8, 0x8e, 0x64, 0xba,
+	0x9c, 0xd7, 0xfd, 0xd7, 0x0a, 0xac, 0x2a, 0x2f, 0x58, 0x1e, 0x06, 0xbd, 0x81, 0xa6, 0x3f, 0x62,
+	0x34, 0x9a, 0x1e, 0xb4, 0xb5, 0xf3, 0x50, 0xc5, 0xd3, 0x17, 0xf4, 0x2a, 0x32, 0xb8, 0xe1, 0x2b,
+	0x2a, 0xfa, 0x25, 0x80, 0x1f, 0x47, 0xb9, 0x52, 0xad, 0x09, 0xd5, 0x67, 0xd5, 0xa3, 0x28, 0x27,
+	0xdb, 0x7d, 0x29, 0x37, 0x67, 0xaa, 0xe9, 0x17, 0x64, 0xf4, 0x5a, 0x01, 0x52, 0x17, 0x80, 0x3c,
+	0xb9, 0xd9, 0x8a, 0xfa, 0x2d, 0xe1, 0xf3, 0x04, 0xda, 0x45, 0x9a, 0x72, 0x16, 0x4a, 0x3c, 0xeb,
+	0xb8, 0xa5, 0x68, 0x2e, 0x0b, 0x29, 0xfa, 0x06, 0x1e, 0x5d, 0xd2, 0x09, 0xe1, 0xae, 0xd2, 0x78,
+	0x44, 0xa2, 0x38, 0xf2, 0x29, 0x09, 0x68, 0x92, 0x52, 0xdf, 0xcb, 0x69, 0x20, 0x70, 0x6d, 0xe3,
+	0x07, 0x97, 0x74, 0xd2, 0x97, 0x22, 0x36, 0x97, 0xd8, 0x9b, 0x0a, 0x20, 0x1b, 0xf4, 0x44, 0x7d,
+	0x67, 0xa4, 0x48, 0xc6, 0x92, 0x08, 0xb3, 0xa3, 0xc2, 0x2c, 0x3e, 0xc3, 0x63, 0xc9, 0xdd, 0x6d,
+	0x1d, 0x9b, 0xd8, 0xb1, 0x06, 0x36, 0xd9, 0x21, 0xcf, 0xf1, 0x5a, 0x52, 0xe5, 0xa2, 0xa7, 0x70,
+	0xf7, 0x5a, 0x40, 0xc6, 0xf2, 0xa6, 0xb6, 0xb5, 0x82, 0xd7, 0xe6, 0xa2, 0x40, 0x2e, 0xac, 0xd3,
+	0xc8, 0x4f, 0x27, 0x49, 0x4e, 0x03, 0x32, 0x4b, 0x53, 0x43, 0x60, 0xfd, 0x63, 0xe5, 0xde, 0x2c,
+	0x24, 0x6e, 0xcc, 0xd7, 0x5d, 0x3a, 0xcf, 0xde, 0xf8, 0x5b, 0x13, 0xee, 0xdf, 0x98, 0x11, 0x74,
+	0x01, 0xe8, 0x7b, 0x16, 0xd0, 0x2b, 0x16, 0x51, 0x92, 0x64, 0xd9, 0x05, 0x09, 0xbc, 0xdc, 0x53,
+	0xb7, 0x62, 0xf7, 0x16, 0xa9, 0xdd, 0xee, 0xd3, 0xc8, 0x9f, 0x61, 0x88, 0xf5, 0xc2, 0xea, 0x30,
+	0xcb, 0x2e, 0xf6, 0xbc, 0xdc, 0x43, 0xbf, 0x86, 0xd6, 0xf7, 0xf4, 0x34, 0xe4, 0x95, 0x62, 0x76,
+	0x7b, 0x6e, 0xe5, 0xe2, 0x84, 0x9e, 0x86, 0x25, 0x17, 0x4d, 0x6e, 0xee, 0x3d, 0x9d, 0x58, 0x01,
+	0x3a, 0x03, 0x9d, 0x7e, 0x64, 0x59, 0xce, 0xa2, 0x73, 0x32, 0x92, 0xa6, 0xc4, 0xc5, 0x6a, 0xed,
+	0x7c, 0x7d, 0x1b, 0x07, 0xa6, 0xb2, 0x51, 0x08, 0xaf, 0xd1, 0x2a, 0x01, 0x7d, 0x07, 0x4d, 0x16,
+	0xb1, 0x5c, 0x82, 0xb4, 0x20, 0x1c, 0xbc, 0xba, 0x8d, 0x03, 0x2b, 0x62, 0x39, 0x07, 0x03, 0x37,
+	0x98, 0x5a, 0x6d, 0xfc, 0x16, 0x56, 0xab, 0xd0, 0x21, 0x04, 0x0b, 0x3c, 0x13, 0x86, 0xb6, 0x59,
+	0xdf, 0x6a, 0x63, 0xb1, 0x46, 0xaf, 0xa1, 0xad, 0xce, 0x45, 0xc4, 0x57, 0x53, 0xfb, 0x64, 0x19,
+	0x69, 0x8d, 0x66, 0x9b, 0xb9, 0xa2, 0x56, 0x9f, 0x2b, 0x6a, 0x1b, 0xbf, 0x83, 0xd5, 0x2a, 0xa6,
+	0xa8, 0x03, 0x4b, 0x17, 0xd4, 0x0b, 0x68, 0xaa, 0x2a, 0xa0, 0xda, 0xfd, 0x9f, 0xfc, 0xff, 0x47,
+	0x83, 0xb5, 0x39, 0xcc, 0xd1, 0xd7, 0x00, 0x85, 0xa7, 0x69, 0x79, 0x7a, 0x54, 0xf5, 0x33, 0x5f,
+	0x54, 0x46, 0x05, 0x19, 0xed, 0xc0, 0xfd, 0x8c, 0xfa, 0x71, 0x14, 0x64, 0x24, 0x63, 0xfc, 0xb3,
+	0xcf, 0x72, 0x2f, 0xe5, 0xdf, 0x7c, 0x4d, 0x94, 0x89, 0x75, 0xc5, 0x74, 0x38, 0xcf, 0x91, 0x2c,
+	0xf4, 0x73, 0x78, 0x50, 0xd5, 0x19, 0x79, 0x59, 0x4e, 0x92, 0x91, 0x37, 0xa1, 0x32, 0xe4, 0x3a,
+	0xee, 0x94, 0xf5, 0x0e, 0xbc, 0x2c, 0x1f, 0x0a, 0xae, 0x54, 0x95, 0x95, 0x78, 0xcc, 0x7b, 0x1c,
+	0xc9, 0xbd, 0xd3, 0x11, 0x25, 0x34, 0xca, 0xd3, 0xa2, 0x1b, 0x76, 0x94, 0xc0, 0x91, 0xe8, 0x81,
+	0x9c, 0x6d, 0x72, 0xee, 0xc6, 0x9f, 0x6a, 0xd0, 0x28, 0x6e, 0x03, 0x0a, 0x61, 0x75, 0x7a, 0xad,
+	0x48, 0xa9, 0x6f, 0xf7, 0x7e, 0xc8, 0xdd, 0x9a, 0x2e, 0x78, 0x06, 0x76, 0x17, 0xfa, 0xa6, 0xdd,
+	0xc7, 0x6d, 0x56, 0xa2, 0xa1, 0x87, 0xe5, 0x5b, 0x2c, 0x5b, 0xd9, 0xf4, 0x3e, 0x5e, 0xcb, 0x74,
+	0xfd, 0x87, 0x64, 0x7a, 0x61, 0x2e, 0xd3, 0xdd, 0x2e, 0xb4, 0xcb, 0x61, 0xa1, 0x06, 0x88, 0xc0,
+	0x74, 0x8d, 0xaf, 0x4e, 0xcc, 0xb7, 0x87, 0x7a, 0xad, 0xfb, 0x1c, 0x5a, 0xa5, 0x7a, 0x8f, 0x96,
+	0xa1, 0x6e, 0x9b, 0x27, 0x72, 0x3e, 0xc0, 0xa6, 0x6d, 0x9e, 0xf4, 0x0e, 0xf4, 0x9a, 0xdc, 0x1c,
+	0x98, 0x3d, 0x3e, 0x18, 0x74, 0xff, 0xd9, 0x86, 0x7b, 0x37, 0x95, 0x40, 0xd4, 0xab, 0x8c, 0x3f,
+	0x3f, 0xf9, 0x4c, 0x77, 0xdb, 0x16, 0x6d, 0x52, 0x40, 0xb5, 0xf4, 0xde, 0xfc, 0xf0, 0x76, 0xf0,
+	0x2b, 0xd5, 0x68, 0xee, 0xc1, 0xa2, 0xec, 0xae, 0x12, 0x20, 0xb9, 0x41, 0xef, 0xa0, 0x55, 0x14,
+	0xe5, 0xe8, 0x2c, 0x36, 0xea, 0x9b, 0xf5, 0xad, 0xd6, 0xe7, 0xed, 0xdb, 0x5e, 0x48, 0x8f, 0xbd,
+	0xd1, 0x98, 0x62, 0x50, 0x7d, 0x34, 0x3a, 0x8b, 0xf9, 0x45, 0x9d, 0x36, 0x73, 0x65, 0x50, 0x7a,
+	0x93, 0xc8, 0xad, 0x17, 0x4c, 0x69, 0x52, 0xc4, 0x88, 0x7e, 0x06, 0x6b, 0x45, 0x66, 0xfc, 0x78,
+	0x1c, 0xe5, 0x34, 0x15, 0xad, 0x6c, 0x05, 0xaf, 0x2a, 0x72, 0x5f, 0x52, 0x11, 0x81, 0x75, 0x65,
+	0xd3, 0xf7, 0x12, 0xef, 0x94, 0x8d, 0x58, 0xce, 0x68, 0x26, 0x5a, 0x58, 0x6b, 0x67, 0xfb, 0x73,
+	0xc1, 0x4a, 0x62, 0xbf, 0xa4, 0x85, 0x91, 0x7f, 0x8d, 0x86, 0x1e, 0x40, 0xe3, 0x2a, 0x4c, 0xe4,
+	0xfd, 0x59, 0x16, 0x01, 0x2f, 0x5f, 0x85, 0x89, 0x28, 0x67, 0xaf, 0xa1, 0x39, 0x3d, 0x31, 0xaf,
+	0x64, 0x91, 0x17, 0xca, 0x34, 0x34, 0xb1, 0x58, 0x73, 0x5c, 0xaf, 0x38, 0x53, 0xe0, 0xda, 0xc4,
+	0x72, 0xb3, 0xf1, 0xe7, 0x06, 0xa0, 0xeb, 0xce, 0xd1, 0x16, 0xb4, 0x2b, 0xe8, 0x70, 0x43, 0x8d,
+	0xdd, 0xc5, 0x33, 0x6f, 0x94, 0x51, 0xdc, 0xf2, 0x4b, 0xe0, 0x3c, 0x85, 0x95, 0xea, 0x50, 0x54,
+	0x2b, 0x8b, 0xb6, 0xb3, 0xd2, 0x4c, 0x84, 0xf6, 0xe1, 0x11, 0x07, 0x37, 0x26, 0x29, 0xcd, 0xe2,
+	0xd1, 0x98, 0x9f, 0x9c, 0x37, 0xe7, 0x2c, 0x4f, 0x3d, 0x16, 0xe5, 0x99, 0x51, 0x2f, 0xab, 0x6e,
+	0x08, 0x51, 0x3c, 0x95, 0xec, 0xcf, 0x04, 0x51, 0x0c, 0x7a, 0xe8, 0x7d, 0x24, 0x17, 0x81, 0x9f,
+	0x4c, 0x07, 0x05, 0x39, 0xe0, 0xed, 0xde, 0x0e, 0xe5, 0xed, 0x6f, 0x03, 0x3f, 0x29, 0x86, 0x89,
+	0xe6, 0xb7, 0x7b, 0xfd, 0x21, 0xb1, 0x07, 0xb6, 0x89, 0x57, 0x43, 0xef, 0x63, 0x89, 0x85, 0x5e,
+	0x42, 0x27, 0xa6, 0x21, 0x11, 0xed, 0x3d, 0x26, 0x5e, 0xc2, 0x48, 0x79, 0x58, 0x5c, 0xc1, 0xeb,
+	0x31, 0x0d, 0xfb, 0x82, 0xd9, 0x4b, 0x58, 0xa1, 0xf4, 0x0b, 0x78, 0xe0, 0x45, 0x39, 0x23, 0x69,
+	0x3c, 0x1a, 0x9d, 0x7a, 0xfe, 0x65, 0xb9, 0x56, 0x19, 0x4b, 0xe5, 0xb3, 0x76, 0xb8, 0x1c, 0x56,
+	0x62, 0xb3, 0x8a, 0x25, 0xe6, 0xfc, 0x34, 0x9c, 0xfa, 0x92, 0xa3, 0x0b, 0x64, 0x69, 0x58, 0xb8,
+	0x78, 0x06, 0xab, 0xbe, 0x17, 0x91, 0x71, 0x12, 0x78, 0x39, 0x25, 0x59, 0x1a, 0x1a, 0x8d, 0xb2,
+	0xdd, 0xb6, 0xef, 0x45, 0x47, 0x82, 0xe7, 0xa4, 0x21, 0xfa, 0xab, 0x06, 0x1b, 0x5e, 0xe4, 0x8d,
+	0xe2, 0x73, 0x12, 0x8f, 0xf3, 0x64, 0x3c, 0x77, 0x4d, 0x41, 0x00, 0xb8, 0x7f, 0x4b, 0x00, 0x7b,
+	0xc2, 0xe0, 0x40, 0xd8, 0x2b, 0x33, 0x76, 0xef, 0xf7, 0xec, 0xde, 0xc1, 0x60, 0x9f, 0x0c, 0x8e,
+	0xdc, 0xe1, 0x91, 0x4b, 0x8e, 0xec, 0xf7, 0xf6, 0xe0, 0xc4, 0xc6, 0x86, 0xf7, 0x09, 0x05, 0x0e,
+	0x17, 0x3f, 0x4b, 0xc0, 0x32, 0x51, 0xcc, 0x2b, 0x91, 0x1a, 0xad, 0x0a, 0x5c, 0xbe, 0x17, 0xed,
+	0x49, 0xb1, 0xb2, 0x7b, 0xf4, 0x12, 0xee, 0xf1, 0x9b, 0x35, 0x4e, 0x7d, 0x4a, 0x52, 0x4f, 0x0c,
+	0x25, 0x39, 0xa3, 0xa9, 0xd1, 0xe6, 0xb8, 0xed, 0x6a, 0xcf, 0x31, 0x2a, 0xd8, 0x58, 0x70, 0x5d,
+	0x46, 0xd3, 0xee, 0xef, 0x35, 0x68, 0x95, 0x53, 0xbd, 0x02, 0xb3, 0x7b, 0xa0, 0xdf, 0xe1, 0x75,
+	0x4f, 0x6c, 0x8f, 0x5f, 0xe8, 0xda, 0x6c, 0xb3, 0xa3, 0xd7, 0xa6, 0x82, 0xc7, 0x3b, 0xe4, 0x85,
+	0x5e, 0x2f, 0x6f, 0x77, 0xf4, 0x85, 0xf2, 0xf6, 0xa5, 0xbe, 0x88, 0x1e, 0x42, 0x47, 0x59, 0x25,
+	0x7b, 0xd6, 0xbe, 0xe5, 0xf6, 0x0e, 0x14, 0x30, 0xfa, 0x7f, 0xb5, 0xee, 0x1f, 0x34, 0x30, 0x3e,
+	0x85, 0x23, 0x7a, 0x00, 0x37, 0x23, 0xa9, 0xdf, 0x41, 0x1d, 0x40, 0x55, 0x96, 0x88, 0x59, 0x43,
+	0x0f, 0xe1, 0x8b, 0x2a, 0xdd, 0x39, 0x1a, 0x0e, 0x07, 0xd8, 0x35, 0xf7, 0xf4, 0x1a, 0x7a, 0x02,
+	0x8f, 0x6f, 0x64, 0x3a, 0xa4, 0xbf, 0x7f, 0xe8, 0x90, 0x9e, 0x5e, 0xef, 0x66, 0xd0, 0x9c, 0x56,
+	0x67, 0x04, 0xa0, 0xea, 0xb3, 0x7e, 0x07, 0x6d, 0x40, 0x67, 0x0f, 0x1f, 0x92, 0x3d, 0xf3, 0xda,
+	0x93, 0x4f, 0x43, 0x5d, 0xf8, 0x12, 0x9b, 0x87, 0x03, 0xd7, 0x24, 0x3d, 0xd7, 0x35, 0x1d, 0xb7,
+	0xe7, 0xf2, 0xb9, 0xbc, 0x2c, 0x53, 0xe3, 0xfa, 0x03, 0xf3, 0x46, 0xfd, 0x7a, 0xf7, 0xef, 0x35,
+	0x78, 0xfc, 0xd9, 0xf9, 0x5a, 0xbc, 0xf5, 0x8a, 0xda, 0xad, 0x46, 0x94, 0x26, 0x86, 0x82, 0x64,
+	0x05, 0xc8, 0x82, 0x27, 0x19, 0x4d, 0xaf, 0x98, 0x4f, 0x89, 0x4f, 0x53, 0xa5, 0x49, 0x49, 0x46,
+	0x53, 0xe6, 0x8d, 0x48, 0x34, 0x0e, 0x4f, 0x69, 0xaa, 0xda, 0xca, 0x97, 0x4a, 0xb0, 0x3f, 0x93,
+	0x73, 0x84, 0x98, 0x2d, 0xa4, 0xd0, 0xf6, 0xcd, 0xcf, 0x01, 0x39, 0x49, 0x5d, 0x1f, 0xf4, 0x79,
+	0x81, 0xb8, 0x41, 0x9e, 0xb0, 0xab, 0xa2, 0xb1, 0x5c, 0x53, 0xb1, 0xae, 0x78, 0x33, 0x9a, 0x29,
+	0x25, 0x29, 0xbb, 0xf2, 0xfc, 0x89, 0x78, 0xd0, 0x2f, 0xce, 0xe9, 0x0c, 0x25, 0x8f, 0xbf, 0xec,
+	0xff, 0xb2, 0x08, 0xfa, 0xc9, 0xfc, 0x88, 0xff, 0x0d, 0x34, 0xbd, 0xd1, 0x79, 0x9c, 0xb2, 0xfc,
+	0x22, 0x34, 0xb4, 0xca, 0xc3, 0x6e, 0x5e, 0x76, 0xbb, 0x57, 0x08, 0xe2, 0x99, 0x0e, 0xfa, 0x02,
+	0x96, 0xe5, 0xf3, 0x20, 0x33, 0x6a, 0x62, 0xfa, 0x5d, 0xba, 0xe4, 0xf3, 0x7d, 0x86, 0x36, 0xa0,
+	0x51, 0x00, 0x2c, 0x0e, 0xdf, 0xc4, 0xd3, 0x3d, 0x1f, 0x3d, 0x4a, 0xaf, 0x52, 0x35, 0x7a, 0xcc,
+	0x1e, 0x9a, 0x8f, 0x01, 0xf2, 0x94, 0x97, 0x3d, 0x31, 0x11, 0x2c, 0x0a, 0xe5, 0xa6, 0xa0, 0x88,
+	0x7b, 0xd5, 0x81, 0xa5, 0x24, 0x1e, 0x31, 0x7f, 0x22, 0x4a, 0x61, 0x13, 0xab, 0x1d, 0x47, 0x5e,
+	0x95, 0xd9, 0x84, 0xa6, 0x2c, 0x0e, 0x08, 0x8b, 0x02, 0xfa, 0x51, 0xd5, 0xbe, 0xbb, 0x92, 0x35,
+	0x14, 0x1c, 0x8b, 0x33, 0x78, 0x77, 0x3e, 0x4f, 0xe3, 0x71, 0x42, 0x83, 0xe9, 0x0b, 0xa4, 0x21,
+	0x42, 0x59, 0x55, 0xe4, 0x62, 0xc0, 0x7d, 0x06, 0x77, 0x39, 0x24, 0xd4, 0x17, 0x7d, 0x27, 0xf3,
+	0x2f, 0x68, 0x48, 0x8d, 0xa6, 0x30, 0xab, 0xcf, 0x18, 0x8e, 0xa0, 0xf3, 0xd4, 0x54, 0xa3, 0x50,
+	0x93, 0xa8, 0xa8, 0x92, 0x2b, 0x78, 0xbd, 0x1c, 0x87, 0x23, 0x59, 0xe8, 0x8d, 0x1a, 0x7e, 0x5a,
+	0x22, 0x01, 0x8f, 0x3e, 0x95, 0x00, 0x39, 0xf3, 0x38, 0x96, 0xbd, 0x7f, 0x60, 0xce, 0x1e, 0xd7,
+	0x1c, 0xfe, 0x8c, 0x4f, 0x61, 0xfc, 0x8d, 0x2a, 0x0a, 0x16, 0x6e, 0x5d, 0xd2, 0x89, 0xa3, 0x48,
+	0x7c, 0x76, 0x14, 0xe7, 0x11, 0x39, 0x5a, 0x11, 0x39, 0x6a, 0x08, 0x02, 0xcf, 0xd2, 0x8f, 0x60,
+	0x45, 0x36, 0xd6, 0x33, 0x2a, 0xff, 0x31, 0x5a, 0x13, 0x90, 0xb6, 0x05, 0xf1, 0x9d, 0xa4, 0x75,
+	0x5f, 0xc3, 0x42, 0xf1, 0x41, 0x4b, 0xe7, 0xfa, 0x1d, 0xfe, 0x3f, 0x8d, 0x69, 0xbb, 0x96, 0x7b,
+	0x60, 0x1e, 0x9a, 0x36, 0xff, 0x4f, 0x48, 0x87, 0xb6, 0x22, 0xec, 0x91, 0xf7, 0xe6, 0x07, 0xbd,
+	0xd6, 0xdd, 0x82, 0xe6, 0xf4, 0xca, 0x70, 0xf9, 0x23, 0x9b, 0x0f, 0xb6, 0x1f, 0x86, 0xbc, 0x9a,
+	0xdc, 0xe1, 0xc6, 0x7a, 0xa6, 0xd3, 0x77, 0xb1, 0xae, 0x3d, 0x7d, 0x03, 0xad, 0xd2, 0x98, 0xca,
+	0x2b, 0xa0, 0xe3, 0x62, 0xb3, 0x77, 0x68, 0xd9, 0xfb, 0xb2, 0x76, 0x0e, 0xde, 0xbd, 0x3b, 0xb0,
+	0x6c, 0x53, 0xd6, 0xce, 0xde, 0x91, 0x3b, 0x38, 0xec, 0xb9, 0x56, 0x5f, 0xaf, 0x3f, 0xdd, 0x83,
+	0xb5, 0xb9, 0x97, 0x3d, 0xf7, 0x54, 0x7a, 0xdb, 0xeb, 0xf7, 0xaa, 0x84, 0x17, 0xfa, 0xfd, 0x2a,
+	0x61, 0x47, 0xef, 0xfc, 0x6f, 0x00, 0x21, 0x1e, 0xac, 0xb9, 0xac, 0x13, 0x00, 0x00,
+}
@@ -1,15 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // source: WidevineCencHeader.proto
 
-/*
-Package proto is a generated protocol buffer package.
-
-It is generated from these files:
-	WidevineCencHeader.proto
-
-It has these top-level messages:
-	WidevineCencHeader
-*/
 package proto
 
 import proto1 "github.com/golang/protobuf/proto"
@@ -21,12 +12,6 @@ var _ = proto1.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
-
 type WidevineCencHeader_Algorithm int32
 
 const (
@@ -60,7 +45,7 @@ func (x *WidevineCencHeader_Algorithm) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (WidevineCencHeader_Algorithm) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{0, 0}
+	return fileDescriptor1, []int{0, 0}
 }
 
 type WidevineCencHeader struct {
@@ -94,7 +79,7 @@ type WidevineCencHeader struct {
 func (m *WidevineCencHeader) Reset()                    { *m = WidevineCencHeader{} }
 func (m *WidevineCencHeader) String() string            { return proto1.CompactTextString(m) }
 func (*WidevineCencHeader) ProtoMessage()               {}
-func (*WidevineCencHeader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*WidevineCencHeader) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
 
 func (m *WidevineCencHeader) GetAlgorithm() WidevineCencHeader_Algorithm {
 	if m != nil && m.Algorithm != nil {
@@ -171,10 +156,10 @@ func init() {
 	proto1.RegisterEnum("proto.WidevineCencHeader_Algorithm", WidevineCencHeader_Algorithm_name, WidevineCencHeader_Algorithm_value)
 }
 
-func init() { proto1.RegisterFile("WidevineCencHeader.proto", fileDescriptor0) }
+func init() { proto1.RegisterFile("WidevineCencHeader.proto", fileDescriptor1) }
 
-var fileDescriptor0 = []byte{
-	// 338 bytes of a gzipped FileDescriptorProto
+var fileDescriptor1 = []byte{
+	// 335 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x5f, 0x4b, 0x02, 0x41,
 	0x14, 0xc5, 0xdb, 0xcc, 0xcd, 0xbd, 0x99, 0x7f, 0x46, 0x8c, 0x21, 0x08, 0x16, 0x7b, 0x68, 0x21,
 	0xf0, 0xc1, 0x6f, 0x20, 0x2a, 0x24, 0x84, 0xc8, 0x6a, 0x44, 0x4f, 0xcb, 0x32, 0x73, 0xd1, 0x41,
@@ -195,6 +180,5 @@ var fileDescriptor0 = []byte{
 	0x5c, 0x6c, 0xeb, 0x04, 0x16, 0x4e, 0x2f, 0x7e, 0xf4, 0xbb, 0x45, 0x86, 0x4c, 0x49, 0x9e, 0x51,
 	0x70, 0x86, 0xce, 0xcf, 0x1e, 0x8b, 0x12, 0xf5, 0x22, 0x08, 0x8e, 0xf7, 0x26, 0x4d, 0xb8, 0x7a,
 	0x99, 0x4d, 0x66, 0xa3, 0xf8, 0x6d, 0xbe, 0x9c, 0x8c, 0x5b, 0x67, 0x04, 0xc0, 0x1f, 0x4e, 0x16,
-	0xa3, 0x65, 0xdc, 0xf2, 0xbe, 0x02, 0x00, 0x00, 0xff, 0xff, 0x20, 0x71, 0x0a, 0xe2, 0xfd, 0x01,
-	0x00, 0x00,
+	0xa3, 0x65, 0xdc, 0xf2, 0xbe, 0x06, 0x00, 0x20, 0x71, 0x0a, 0xe2, 0xfd, 0x01, 0x00, 0x00,
 }
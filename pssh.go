@@ -0,0 +1,313 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// WidevineSystemID is the DRM system ID of Widevine,
+// edef8ba9-79d6-4ace-a3c8-27dcd51d21ed.
+var WidevineSystemID = [16]byte{
+	0xed, 0xef, 0x8b, 0xa9, 0x79, 0xd6, 0x4a, 0xce,
+	0xa3, 0xc8, 0x27, 0xdc, 0xd5, 0x1d, 0x21, 0xed}
+
+// Size of a key ID in a PSSH box.
+const keyIDSize = 16
+
+var errKeyIDSize = errors.New("widevine: key IDs must be 16 bytes")
+
+// PSSH is a Protection System Specific Header box as defined by
+// ISO/IEC 23001-7. Key IDs are only written to version 1 boxes.
+type PSSH struct {
+	Version  uint8
+	Flags    uint32
+	SystemID [16]byte
+	KeyIDs   [][]byte
+	Data     []byte
+}
+
+// PSSHOptions sets the fields of a Widevine PSSH box built with BuildPSSH.
+// Version is the PSSH box version, 0 or 1. Key IDs are always written to the
+// WidevineCencHeader and, for version 1, to the box as well.
+type PSSHOptions struct {
+	Version             uint8
+	KeyIDs              [][]byte
+	Provider            string
+	ContentID           []byte
+	Policy              string
+	ProtectionScheme    ProtectionScheme
+	CryptoPeriodIndex   *uint32
+	CryptoPeriodSeconds uint32
+}
+
+// BuildPSSH builds a Widevine PSSH box from opts.
+func BuildPSSH(opts PSSHOptions) (*PSSH, error) {
+	if opts.Version > 1 {
+		return nil, fmt.Errorf("widevine: unsupported PSSH version %d", opts.Version)
+	}
+	for _, kid := range opts.KeyIDs {
+		if len(kid) != keyIDSize {
+			return nil, errKeyIDSize
+		}
+	}
+	if err := ValidateProtectionScheme(opts.ProtectionScheme, nil); err != nil {
+		return nil, err
+	}
+
+	header := &proto.WidevineCencHeader{
+		KeyId:     opts.KeyIDs,
+		ContentId: opts.ContentID,
+	}
+	if opts.Provider != "" {
+		header.Provider = protobuf.String(opts.Provider)
+	}
+	if opts.Policy != "" {
+		header.Policy = protobuf.String(opts.Policy)
+	}
+	if opts.ProtectionScheme != 0 {
+		header.ProtectionScheme = protobuf.Uint32(uint32(opts.ProtectionScheme))
+	}
+	if opts.CryptoPeriodIndex != nil {
+		header.CryptoPeriodIndex = protobuf.Uint32(*opts.CryptoPeriodIndex)
+	}
+	if opts.CryptoPeriodSeconds != 0 {
+		header.CryptoPeriodSeconds = protobuf.Uint32(opts.CryptoPeriodSeconds)
+	}
+
+	data, err := protobuf.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PSSH{
+		Version:  opts.Version,
+		SystemID: WidevineSystemID,
+		Data:     data,
+	}
+	if opts.Version == 1 {
+		p.KeyIDs = opts.KeyIDs
+	}
+	return p, nil
+}
+
+// Bytes returns the serialized box.
+func (p *PSSH) Bytes() ([]byte, error) {
+	if p.Version > 1 {
+		return nil, fmt.Errorf("widevine: unsupported PSSH version %d", p.Version)
+	}
+	if p.Flags > 0xffffff {
+		return nil, errors.New("widevine: PSSH flags must fit in 24 bits")
+	}
+
+	size := 8 + 4 + 16 + 4 + len(p.Data)
+	if p.Version == 1 {
+		size += 4 + keyIDSize*len(p.KeyIDs)
+	}
+
+	b := make([]byte, 0, size)
+	b = appendUint32(b, uint32(size))
+	b = append(b, "pssh"...)
+	b = appendUint32(b, uint32(p.Version)<<24|p.Flags)
+	b = append(b, p.SystemID[:]...)
+	if p.Version == 1 {
+		b = appendUint32(b, uint32(len(p.KeyIDs)))
+		for _, kid := range p.KeyIDs {
+			if len(kid) != keyIDSize {
+				return nil, errKeyIDSize
+			}
+			b = append(b, kid...)
+		}
+	}
+	b = appendUint32(b, uint32(len(p.Data)))
+	b = append(b, p.Data...)
+	return b, nil
+}
+
+// Base64 returns the serialized box encoded as standard base64, as used in
+// DASH manifests.
+func (p *PSSH) Base64() (string, error) {
+	b, err := p.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Hex returns the serialized box encoded as hex.
+func (p *PSSH) Hex() (string, error) {
+	b, err := p.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// ParsePSSH parses a PSSH box, or several concatenated PSSH boxes, from b.
+func ParsePSSH(b []byte) ([]*PSSH, error) {
+	var boxes []*PSSH
+	for len(b) > 0 {
+		p, n, err := parsePSSHBox(b)
+		if err != nil {
+			return nil, err
+		}
+		boxes = append(boxes, p)
+		b = b[n:]
+	}
+	if len(boxes) == 0 {
+		return nil, errors.New("widevine: no PSSH box found")
+	}
+	return boxes, nil
+}
+
+// ParsePSSHBase64 parses base64 encoded PSSH boxes, as found in manifests.
+func ParsePSSHBase64(s string) ([]*PSSH, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	return ParsePSSH(b)
+}
+
+// parsePSSHBox parses a single box at the start of b and returns the number
+// of bytes it occupies.
+func parsePSSHBox(b []byte) (*PSSH, int, error) {
+	if len(b) < 32 {
+		return nil, 0, errors.New("widevine: PSSH box too short")
+	}
+
+	size := int(binary.BigEndian.Uint32(b))
+	if size == 0 {
+		size = len(b)
+	}
+	if size < 32 || size > len(b) {
+		return nil, 0, fmt.Errorf("widevine: invalid PSSH box size %d", size)
+	}
+	if string(b[4:8]) != "pssh" {
+		return nil, 0, fmt.Errorf("widevine: unexpected box type %q", b[4:8])
+	}
+
+	p := &PSSH{
+		Version: b[8],
+		Flags:   binary.BigEndian.Uint32(b[8:12]) & 0xffffff,
+	}
+	if p.Version > 1 {
+		return nil, 0, fmt.Errorf("widevine: unsupported PSSH version %d", p.Version)
+	}
+	copy(p.SystemID[:], b[12:28])
+
+	box := b[:size]
+	off := 28
+	if p.Version == 1 {
+		count := int(binary.BigEndian.Uint32(box[off:]))
+		off += 4
+		if count > (len(box)-off)/keyIDSize {
+			return nil, 0, fmt.Errorf("widevine: invalid PSSH key ID count %d", count)
+		}
+		for i := 0; i < count; i++ {
+			p.KeyIDs = append(p.KeyIDs, box[off:off+keyIDSize])
+			off += keyIDSize
+		}
+	}
+
+	if len(box)-off < 4 {
+		return nil, 0, errors.New("widevine: PSSH box truncated")
+	}
+	dataSize := int(binary.BigEndian.Uint32(box[off:]))
+	off += 4
+	if dataSize != len(box)-off {
+		return nil, 0, fmt.Errorf("widevine: PSSH data size %d does not match box size %d", dataSize, size)
+	}
+	p.Data = box[off:]
+	return p, size, nil
+}
+
+// IsWidevine reports whether the box carries the Widevine system ID.
+func (p *PSSH) IsWidevine() bool {
+	return p.SystemID == WidevineSystemID
+}
+
+// WidevineHeader decodes the data of a Widevine PSSH box.
+func (p *PSSH) WidevineHeader() (*proto.WidevineCencHeader, error) {
+	if !p.IsWidevine() {
+		return nil, fmt.Errorf("widevine: PSSH system ID %s is not Widevine", formatUUID(p.SystemID[:]))
+	}
+	header := &proto.WidevineCencHeader{}
+	if err := protobuf.Unmarshal(p.Data, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// String describes the box for debugging. Widevine boxes include the
+// decoded provider, content ID, key IDs and protection scheme.
+func (p *PSSH) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "pssh version=%d flags=%d system_id=%s", p.Version, p.Flags, formatUUID(p.SystemID[:]))
+	for _, kid := range p.KeyIDs {
+		fmt.Fprintf(&sb, " kid=%s", formatUUID(kid))
+	}
+	if !p.IsWidevine() {
+		fmt.Fprintf(&sb, " data=%s", hex.EncodeToString(p.Data))
+		return sb.String()
+	}
+
+	header, err := p.WidevineHeader()
+	if err != nil {
+		fmt.Fprintf(&sb, " data=%s (%v)", hex.EncodeToString(p.Data), err)
+		return sb.String()
+	}
+	if header.Algorithm != nil {
+		fmt.Fprintf(&sb, " algorithm=%s", header.GetAlgorithm())
+	}
+	for _, kid := range header.GetKeyId() {
+		fmt.Fprintf(&sb, " key_id=%s", formatUUID(kid))
+	}
+	if header.Provider != nil {
+		fmt.Fprintf(&sb, " provider=%s", header.GetProvider())
+	}
+	if header.ContentId != nil {
+		fmt.Fprintf(&sb, " content_id=%q", header.GetContentId())
+	}
+	if header.Policy != nil {
+		fmt.Fprintf(&sb, " policy=%s", header.GetPolicy())
+	}
+	if header.ProtectionScheme != nil {
+		fmt.Fprintf(&sb, " protection_scheme=%s", ProtectionScheme(header.GetProtectionScheme()))
+	}
+	if header.CryptoPeriodIndex != nil {
+		fmt.Fprintf(&sb, " crypto_period_index=%d", header.GetCryptoPeriodIndex())
+	}
+	if header.CryptoPeriodSeconds != nil {
+		fmt.Fprintf(&sb, " crypto_period_seconds=%d", header.GetCryptoPeriodSeconds())
+	}
+	return sb.String()
+}
+
+// fourCC formats a 4CC value such as a protection scheme.
+func fourCC(v uint32) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	return string(b[:])
+}
+
+// formatUUID formats 16 bytes as a UUID, or other lengths as hex.
+func formatUUID(b []byte) string {
+	if len(b) != 16 {
+		return hex.EncodeToString(b)
+	}
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
@@ -0,0 +1,145 @@
+package widevine
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+var testKeyID = []byte{
+	0x6e, 0x5a, 0x1d, 0x26, 0x27, 0x57, 0x47, 0xd7,
+	0x80, 0x46, 0xea, 0xa5, 0xd1, 0xd3, 0x4b, 0x5a}
+
+func TestBuildPSSHVersion0(t *testing.T) {
+	p, err := BuildPSSH(PSSHOptions{
+		Provider:  "widevine_test",
+		ContentID: []byte("testing"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := p.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 0x20 bytes header + 0x18 bytes of WidevineCencHeader data.
+	want := "00000038" + "70737368" + "00000000" +
+		"edef8ba979d64acea3c827dcd51d21ed" + "00000018" +
+		"1a0d7769646576696e655f74657374" + "2207" + hex.EncodeToString([]byte("testing"))
+	if out != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestBuildPSSHVersion1(t *testing.T) {
+	p, err := BuildPSSH(PSSHOptions{
+		Version:  1,
+		KeyIDs:   [][]byte{testKeyID},
+		Provider: "widevine_test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := p.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(b[3]) != len(b) {
+		t.Errorf("box size %d does not match length %d", b[3], len(b))
+	}
+	if b[8] != 1 {
+		t.Error("expected version 1")
+	}
+	if b[31] != 1 || !bytes.Equal(b[32:48], testKeyID) {
+		t.Error("expected a single key ID in box")
+	}
+	if _, err := p.Base64(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuildPSSHInvalidKeyID(t *testing.T) {
+	_, err := BuildPSSH(PSSHOptions{KeyIDs: [][]byte{{0x01, 0x02}}})
+	if err != errKeyIDSize {
+		t.Errorf("expected errKeyIDSize, got %v", err)
+	}
+
+	_, err = BuildPSSH(PSSHOptions{Version: 2})
+	if err == nil {
+		t.Error("expected error for version 2")
+	}
+}
+
+func TestParsePSSH(t *testing.T) {
+	v0, _ := BuildPSSH(PSSHOptions{
+		KeyIDs:           [][]byte{testKeyID},
+		Provider:         "widevine_test",
+		ContentID:        []byte("testing"),
+		ProtectionScheme: 0x63656e63,
+	})
+	v1, _ := BuildPSSH(PSSHOptions{Version: 1, KeyIDs: [][]byte{testKeyID}})
+	b0, _ := v0.Bytes()
+	b1, _ := v1.Bytes()
+
+	boxes, err := ParsePSSH(append(b0, b1...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boxes) != 2 {
+		t.Fatalf("expected 2 boxes, got %d", len(boxes))
+	}
+	if !boxes[0].IsWidevine() || boxes[0].Version != 0 || boxes[1].Version != 1 {
+		t.Error()
+	}
+	if len(boxes[1].KeyIDs) != 1 || !bytes.Equal(boxes[1].KeyIDs[0], testKeyID) {
+		t.Error("expected key ID in version 1 box")
+	}
+
+	header, err := boxes[0].WidevineHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.GetProvider() != "widevine_test" || string(header.GetContentId()) != "testing" {
+		t.Error()
+	}
+
+	want := "pssh version=0 flags=0 system_id=edef8ba9-79d6-4ace-a3c8-27dcd51d21ed" +
+		" key_id=6e5a1d26-2757-47d7-8046-eaa5d1d34b5a provider=widevine_test" +
+		` content_id="testing" protection_scheme=cenc`
+	if s := boxes[0].String(); s != want {
+		t.Errorf("got %s, want %s", s, want)
+	}
+}
+
+func TestParsePSSHBase64(t *testing.T) {
+	p, _ := BuildPSSH(PSSHOptions{Provider: "widevine_test"})
+	enc, _ := p.Base64()
+
+	boxes, err := ParsePSSHBase64(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boxes) != 1 || !bytes.Equal(boxes[0].Data, p.Data) {
+		t.Error()
+	}
+}
+
+func TestParsePSSHInvalid(t *testing.T) {
+	p, _ := BuildPSSH(PSSHOptions{Provider: "widevine_test"})
+	b, _ := p.Bytes()
+
+	tests := map[string][]byte{
+		"empty":     {},
+		"truncated": b[:len(b)-1],
+		"type":      append(append([]byte{}, b[:4]...), append([]byte("moov"), b[8:]...)...),
+		"version":   append(append([]byte{}, b[:8]...), append([]byte{2}, b[9:]...)...),
+	}
+	for name, in := range tests {
+		if _, err := ParsePSSH(in); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
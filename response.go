@@ -0,0 +1,198 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// GetContentKeyResponse JSON response from Widevine Cloud.
+// /cenc/getcontentkey/<provider>
+//
+// SigningKeyID is the ID of the key the request was signed with, when the
+// signer is a KeyedSigner. It is also set when the request fails.
+type GetContentKeyResponse struct {
+	Status       Status  `json:"status"`
+	DRM          []DRM   `json:"drm"`
+	Tracks       []Track `json:"tracks"`
+	AlreadyUsed  bool    `json:"already_used"`
+	SigningKeyID string  `json:"-"`
+}
+
+// DRM is a DRM system of a content key response.
+type DRM struct {
+	Type     string `json:"type"`
+	SystemID string `json:"system_id"`
+}
+
+// Track is the key of one track type in a content key response. KeyID and
+// Key are base64 encoded.
+type Track struct {
+	Type              string      `json:"type"`
+	KeyID             string      `json:"key_id"`
+	Key               string      `json:"key"`
+	PSSH              []TrackPSSH `json:"pssh"`
+	CryptoPeriodIndex uint32      `json:"crypto_period_index"`
+}
+
+// TrackPSSH is the base64 encoded PSSH data of a track for one DRM system.
+type TrackPSSH struct {
+	DRMType string `json:"drm_type"`
+	Data    string `json:"data"`
+}
+
+// GetLicenseResponse decoded JSON response from Widevine Cloud.
+// /cenc/getlicense
+//
+// SigningKeyID is the ID of the key the request was signed with, when the
+// signer is a KeyedSigner. It is also set when the request fails.
+type GetLicenseResponse struct {
+	Status                     Status           `json:"status"`
+	License                    string           `json:"license"`
+	Make                       string           `json:"make"`
+	Model                      string           `json:"model"`
+	SecurityLevel              int              `json:"security_level"`
+	InternalStatus             int              `json:"internal_status"`
+	DRMCertSerialNumber        string           `json:"drm_cert_serial_number"`
+	DeviceWhitelistState       string           `json:"device_whitelist_state"`
+	MessageType                string           `json:"message_type"`
+	Platform                   string           `json:"platform"`
+	DeviceState                string           `json:"device_state"`
+	ClientMaxHDCPVersion       string           `json:"client_max_hdcp_version"`
+	PlatformVerificationStatus string           `json:"platform_verification_status"`
+	ContentOwner               string           `json:"content_owner"`
+	ContentProvider            string           `json:"content_provider"`
+	SessionState               SessionState     `json:"session_state"`
+	LicenseMetadata            LicenseMetadata  `json:"license_metadata"`
+	SupportedTracks            []SupportedTrack `json:"supported_tracks"`
+	PSSHData                   PSSHData         `json:"pssh_data"`
+	ClientInfo                 []ClientInfo     `json:"client_info"`
+	SigningKeyID               string           `json:"-"`
+}
+
+// LicenseMetadata describes the license issued. ContentID is base64
+// encoded.
+type LicenseMetadata struct {
+	ContentID   string `json:"content_id"`
+	LicenseType string `json:"license_type"`
+	RequestType string `json:"request_type"`
+}
+
+// SupportedTrack is a track the license grants a key for. KeyID is base64
+// encoded.
+type SupportedTrack struct {
+	Type  string `json:"type"`
+	KeyID string `json:"key_id"`
+}
+
+// SessionState identifies the session of a license, for renewals.
+type SessionState struct {
+	LicenseID      LicenseID `json:"license_id"`
+	SigningKey     string    `json:"signing_key"`
+	KeyboxSystemID int       `json:"keybox_system_id"`
+	LicenseCounter int       `json:"license_counter"`
+}
+
+// LicenseID identifies a license. RequestID, SessionID and PurchaseID are
+// base64 encoded.
+type LicenseID struct {
+	RequestID  string `json:"request_id"`
+	SessionID  string `json:"session_id"`
+	PurchaseID string `json:"purchase_id"`
+	Type       string `json:"type"`
+	Version    int    `json:"version"`
+}
+
+// PSSHData is the PSSH data of a license request. KeyID and ContentID are
+// base64 encoded.
+type PSSHData struct {
+	KeyID     string `json:"key_id"`
+	ContentID string `json:"content_id"`
+}
+
+// ClientInfo is a name and value reported by the client.
+type ClientInfo struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// decodeField decodes a base64 field of a response.
+func decodeField(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, &DecodeError{Body: []byte(s), Err: err}
+	}
+	return b, nil
+}
+
+// Track returns the track of type trackType, or false if there is none.
+// With key rotation the track with the lowest crypto period index is
+// returned.
+func (r GetContentKeyResponse) Track(trackType string) (Track, bool) {
+	var track Track
+	found := false
+	for _, t := range r.Tracks {
+		if t.Type == trackType && (!found || t.CryptoPeriodIndex < track.CryptoPeriodIndex) {
+			track, found = t, true
+		}
+	}
+	return track, found
+}
+
+// KeyIDBytes returns the decoded key ID.
+func (t Track) KeyIDBytes() ([]byte, error) { return decodeField(t.KeyID) }
+
+// KeyBytes returns the decoded content key.
+func (t Track) KeyBytes() ([]byte, error) { return decodeField(t.Key) }
+
+// WidevinePSSH returns the Widevine PSSH box of the track.
+func (t Track) WidevinePSSH() (*PSSH, error) {
+	for _, p := range t.PSSH {
+		if p.DRMType == "WIDEVINE" {
+			return p.Box()
+		}
+	}
+	return nil, fmt.Errorf("widevine: track %s has no Widevine PSSH", t.Type)
+}
+
+// Bytes returns the decoded PSSH data.
+func (p TrackPSSH) Bytes() ([]byte, error) { return decodeField(p.Data) }
+
+// Box returns the PSSH as a parsed box. Widevine returns the data of
+// Widevine boxes without the box header, which is added here.
+func (p TrackPSSH) Box() (*PSSH, error) {
+	b, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if boxes, err := ParsePSSH(b); err == nil {
+		return boxes[0], nil
+	}
+	if p.DRMType != "WIDEVINE" {
+		return nil, fmt.Errorf("widevine: %s PSSH data is not a PSSH box", p.DRMType)
+	}
+	return &PSSH{SystemID: WidevineSystemID, Data: b}, nil
+}
+
+// LicenseBytes returns the decoded license, as sent to the player.
+func (r GetLicenseResponse) LicenseBytes() ([]byte, error) { return decodeField(r.License) }
+
+// ContentIDBytes returns the decoded content ID.
+func (m LicenseMetadata) ContentIDBytes() ([]byte, error) { return decodeField(m.ContentID) }
+
+// KeyIDBytes returns the decoded key ID.
+func (t SupportedTrack) KeyIDBytes() ([]byte, error) { return decodeField(t.KeyID) }
+
+// RequestIDBytes returns the decoded request ID.
+func (id LicenseID) RequestIDBytes() ([]byte, error) { return decodeField(id.RequestID) }
+
+// SessionIDBytes returns the decoded session ID.
+func (id LicenseID) SessionIDBytes() ([]byte, error) { return decodeField(id.SessionID) }
+
+// PurchaseIDBytes returns the decoded purchase ID.
+func (id LicenseID) PurchaseIDBytes() ([]byte, error) { return decodeField(id.PurchaseID) }
+
+// KeyIDBytes returns the decoded key ID.
+func (d PSSHData) KeyIDBytes() ([]byte, error) { return decodeField(d.KeyID) }
+
+// ContentIDBytes returns the decoded content ID.
+func (d PSSHData) ContentIDBytes() ([]byte, error) { return decodeField(d.ContentID) }
@@ -0,0 +1,93 @@
+package widevine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestContentKeyResponseAccessors(t *testing.T) {
+	var resp GetContentKeyResponse
+	err := json.Unmarshal([]byte(`{
+		"status": "OK",
+		"tracks": [{
+			"type": "SD",
+			"key_id": "blodJidXR9eARuql0dNLWg==",
+			"key": "AAECAwQFBgcICQoLDA0ODw==",
+			"pssh": [{"drm_type": "WIDEVINE", "data": "CAESEG5aHSYnV0fXgEbqpdHTS1o="}]
+		}]
+	}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	track, ok := resp.Track("SD")
+	if !ok {
+		t.Fatal("expected SD track")
+	}
+	if kid, err := track.KeyIDBytes(); err != nil || !bytes.Equal(kid, testKeyID) {
+		t.Errorf("got key ID %x, %v", kid, err)
+	}
+	if key, err := track.KeyBytes(); err != nil || len(key) != contentKeySize {
+		t.Errorf("got key %x, %v", key, err)
+	}
+
+	box, err := track.WidevinePSSH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := box.WidevineHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(header.KeyId) != 1 || !bytes.Equal(header.KeyId[0], testKeyID) {
+		t.Errorf("got key IDs %x", header.KeyId)
+	}
+
+	if _, ok := resp.Track("HD"); ok {
+		t.Error("unexpected HD track")
+	}
+}
+
+func TestContentKeyResponseTrackRotation(t *testing.T) {
+	resp := GetContentKeyResponse{Tracks: []Track{
+		{Type: "SD", KeyID: "b", CryptoPeriodIndex: 8},
+		{Type: "HD", KeyID: "c", CryptoPeriodIndex: 6},
+		{Type: "SD", KeyID: "a", CryptoPeriodIndex: 7},
+	}}
+	if track, ok := resp.Track("SD"); !ok || track.CryptoPeriodIndex != 7 || track.KeyID != "a" {
+		t.Errorf("got %+v, %v, want period 7", track, ok)
+	}
+	if _, ok := resp.Track("UHD1"); ok {
+		t.Error("expected no UHD1 track")
+	}
+}
+
+func TestLicenseResponseAccessors(t *testing.T) {
+	var resp GetLicenseResponse
+	err := json.Unmarshal([]byte(`{
+		"status": "OK",
+		"license": "bGljZW5zZQ==",
+		"content_provider": "widevine_test",
+		"session_state": {"license_id": {"session_id": "c2Vzc2lvbg==", "request_id": "!"}}
+	}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.ContentProvider != "widevine_test" {
+		t.Errorf("got content provider %q", resp.ContentProvider)
+	}
+	if license, err := resp.LicenseBytes(); err != nil || string(license) != "license" {
+		t.Errorf("got license %q, %v", license, err)
+	}
+	if id, err := resp.SessionState.LicenseID.SessionIDBytes(); err != nil || string(id) != "session" {
+		t.Errorf("got session ID %q, %v", id, err)
+	}
+
+	var decodeErr *DecodeError
+	if _, err := resp.SessionState.LicenseID.RequestIDBytes(); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError, got %v", err)
+	}
+}
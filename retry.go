@@ -0,0 +1,151 @@
+package widevine
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures retries of failed Widevine Cloud calls.
+//
+// Content key requests are retried on any transport error, on any of
+// RetryableStatusCodes and on a retryable Widevine status (see
+// Status.Retryable), which is sent with HTTP 200. License requests are not
+// idempotent, so they are only retried when the request cannot have been
+// processed: when the connection could not be established, or when the
+// service answered 429 or 503 and the status is listed in
+// RetryableStatusCodes.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Each following
+	// delay is multiplied by Multiplier and capped at MaxBackoff. MaxBackoff
+	// also caps the delay a server asks for with Retry-After.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter is the fraction of each delay, between 0 and 1, that is
+	// randomized to avoid synchronized retries.
+	Jitter float64
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts with
+// exponential backoff starting at 200ms and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before retry number n, starting at 1.
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff)
+	for i := 1; i < n; i++ {
+		d *= multiplier
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) retryableStatus(code int, idempotent bool) bool {
+	if !idempotent && code != http.StatusTooManyRequests && code != http.StatusServiceUnavailable {
+		return false
+	}
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableError(err error, idempotent bool) bool {
+	if idempotent {
+		return true
+	}
+
+	// Only retry when the request never reached the server.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done. It does not wait at all when ctx
+// would expire before d elapses.
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,245 @@
+package widevine
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfg/widevine/widevinetest"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.Jitter = 0
+	return p
+}
+
+func failingServer(failures int, status int, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprintln(w, `{"test": "testing123"}`)
+	}))
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	var calls int
+	ts := failingServer(2, http.StatusInternalServerError, &calls)
+	defer ts.Close()
+
+	client, _ := NewClient()
+	client.Retry = testRetryPolicy()
+
+	resp := testResponse{}
+	err := client.postIdempotentContext(context.Background(), ts.URL, &resp, map[string]interface{}{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || resp.Test != "testing123" {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	var calls int
+	ts := failingServer(1, http.StatusInternalServerError, &calls)
+	defer ts.Close()
+
+	client, _ := NewClient()
+	client.Retry = testRetryPolicy()
+
+	resp := testResponse{}
+	if err := client.post(ts.URL, &resp, map[string]interface{}{}); err == nil {
+		t.Error("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+
+	// 503 means the request was not processed and is safe to retry.
+	calls = 0
+	ts503 := failingServer(1, http.StatusServiceUnavailable, &calls)
+	defer ts503.Close()
+	if err := client.post(ts503.URL, &resp, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	ts := failingServer(10, http.StatusBadGateway, &calls)
+	defer ts.Close()
+
+	client, _ := NewClient()
+	client.Retry = testRetryPolicy()
+
+	resp := testResponse{}
+	err := client.getContext(context.Background(), ts.URL, &resp)
+	if _, ok := err.(*HTTPStatusError); !ok {
+		t.Errorf("expected *HTTPStatusError, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"test": "testing123"}`)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient()
+	client.Retry = testRetryPolicy()
+	client.Retry.MaxBackoff = 10 * time.Millisecond
+
+	start := time.Now()
+	resp := testResponse{}
+	if err := client.post(ts.URL, &resp, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry-After not capped at MaxBackoff, waited %s", elapsed)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryEmptyStatus(t *testing.T) {
+	// Test doubles often leave Status empty and only set StatusCode.
+	var calls int
+	client, _ := NewClient()
+	client.Retry = testRetryPolicy()
+	client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		code := http.StatusOK
+		if calls == 1 {
+			code = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"test": "testing123"}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	resp := testResponse{}
+	if err := client.post("http://example.com", &resp, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || resp.Test != "testing123" {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryWidevineStatus(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+	ts.Enqueue(widevinetest.Response{Status: "INTERNAL_ERROR"})
+
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: ts.URL, Retry: testRetryPolicy()})
+	if _, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(ts.Requests()); n != 2 {
+		t.Errorf("expected 2 content key requests, got %d", n)
+	}
+
+	// License requests are not idempotent and are not retried on a status.
+	ts.Enqueue(widevinetest.Response{Status: "INTERNAL_ERROR"})
+	if _, err := wv.RequestLicense("testing", testLicenseChallenge); !IsRetryable(err) {
+		t.Errorf("expected retryable error, got %v", err)
+	}
+	if n := len(ts.Requests()); n != 3 {
+		t.Errorf("expected 1 license request, got %d", n-2)
+	}
+
+	// Permanent statuses are not retried.
+	ts.Enqueue(widevinetest.Response{Status: "POLICY_UNKNOWN"})
+	if _, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}}); err == nil {
+		t.Error("expected error")
+	}
+	if n := len(ts.Requests()); n != 4 {
+		t.Errorf("expected 1 content key request, got %d", n-3)
+	}
+}
+
+func TestRetryMixedBudget(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+	for i := 0; i < 3; i++ {
+		ts.Enqueue(
+			widevinetest.Response{HTTPStatus: http.StatusServiceUnavailable},
+			widevinetest.Response{HTTPStatus: http.StatusServiceUnavailable},
+			widevinetest.Response{Status: "INTERNAL_ERROR"},
+		)
+	}
+
+	// HTTP errors and Widevine statuses share one budget of MaxAttempts.
+	policy := testRetryPolicy()
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: ts.URL, Retry: policy})
+	if _, err := wv.RequestContentKey("testing", Policy{Tracks: []string{"SD"}}); !IsRetryable(err) {
+		t.Errorf("expected retryable error, got %v", err)
+	}
+	if n := len(ts.Requests()); n > policy.MaxAttempts {
+		t.Errorf("got %d requests, want at most %d", n, policy.MaxAttempts)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w*time.Millisecond {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w*time.Millisecond)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Errorf("jittered backoff out of range: %s", got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	h := http.Header{}
+	if _, ok := retryAfter(h); ok {
+		t.Error()
+	}
+
+	h.Set("Retry-After", "3")
+	if d, ok := retryAfter(h); !ok || d != 3*time.Second {
+		t.Error(d)
+	}
+
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if d, ok := retryAfter(h); !ok || d < 59*time.Minute {
+		t.Error(d)
+	}
+}
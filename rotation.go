@@ -0,0 +1,107 @@
+package widevine
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// KeyRotation selects the crypto periods requested by RequestRotatingKeys.
+// Count periods are requested starting at FirstIndex. PeriodSeconds is the
+// duration of each period; when set, it is sent as crypto_period_seconds and
+// written to the PSSH of each period.
+type KeyRotation struct {
+	FirstIndex    uint32
+	Count         int
+	PeriodSeconds uint32
+}
+
+// CryptoPeriod holds the keys and PSSH of every track for one crypto period.
+// Seconds is the PeriodSeconds of the rotation that requested it.
+type CryptoPeriod struct {
+	Index   uint32
+	Seconds uint32
+	Tracks  []Track
+}
+
+// KeyRotationResponse is a content key response split by crypto period.
+// Periods are sorted by index.
+type KeyRotationResponse struct {
+	GetContentKeyResponse
+	Periods []CryptoPeriod
+}
+
+// RequestRotatingKeys requests content keys for rotation.Count crypto
+// periods of contentID, starting at rotation.FirstIndex.
+func (wp *Widevine) RequestRotatingKeys(contentID string, policy Policy, rotation KeyRotation) (KeyRotationResponse, error) {
+	return wp.RequestRotatingKeysContext(context.Background(), contentID, policy, rotation)
+}
+
+// RequestRotatingKeysContext is like RequestRotatingKeys but uses ctx for
+// the request.
+func (wp *Widevine) RequestRotatingKeysContext(ctx context.Context, contentID string, policy Policy, rotation KeyRotation) (KeyRotationResponse, error) {
+	if rotation.Count < 1 {
+		return KeyRotationResponse{}, errors.New("widevine: key rotation requires at least one crypto period")
+	}
+	if err := ValidateProtectionScheme(policy.ProtectionScheme, policy.DRMTypes); err != nil {
+		return KeyRotationResponse{}, err
+	}
+
+	p := wp.setPolicy(contentID, policy)
+	p["first_crypto_period_index"] = rotation.FirstIndex
+	p["crypto_period_count"] = rotation.Count
+	if rotation.PeriodSeconds > 0 {
+		p["crypto_period_seconds"] = rotation.PeriodSeconds
+	}
+
+	msg, err := wp.buildCKMessage(ctx, p)
+	if err != nil {
+		return KeyRotationResponse{}, err
+	}
+	resp, err := wp.getContentKeyRequest(ctx, msg)
+	return KeyRotationResponse{
+		GetContentKeyResponse: resp,
+		Periods:               groupCryptoPeriods(resp.Tracks, rotation.PeriodSeconds),
+	}, err
+}
+
+func groupCryptoPeriods(t []Track, seconds uint32) []CryptoPeriod {
+	byIndex := make(map[uint32]*CryptoPeriod)
+	var periods []*CryptoPeriod
+	for _, track := range t {
+		period, ok := byIndex[track.CryptoPeriodIndex]
+		if !ok {
+			period = &CryptoPeriod{Index: track.CryptoPeriodIndex, Seconds: seconds}
+			byIndex[track.CryptoPeriodIndex] = period
+			periods = append(periods, period)
+		}
+		period.Tracks = append(period.Tracks, track)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Index < periods[j].Index })
+
+	out := make([]CryptoPeriod, 0, len(periods))
+	for _, period := range periods {
+		out = append(out, *period)
+	}
+	return out
+}
+
+// PSSH builds the Widevine PSSH box of the period. The key IDs of all tracks,
+// the period index and, when Seconds is set, the period duration are set
+// from the period; the remaining fields are taken from opts.
+func (c CryptoPeriod) PSSH(opts PSSHOptions) (*PSSH, error) {
+	opts.KeyIDs = nil
+	for _, track := range c.Tracks {
+		kid, err := track.KeyIDBytes()
+		if err != nil {
+			return nil, err
+		}
+		opts.KeyIDs = append(opts.KeyIDs, kid)
+	}
+	index := c.Index
+	opts.CryptoPeriodIndex = &index
+	if c.Seconds > 0 {
+		opts.CryptoPeriodSeconds = c.Seconds
+	}
+	return BuildPSSH(opts)
+}
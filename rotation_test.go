@@ -0,0 +1,64 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestRotatingKeys(t *testing.T) {
+	kid := base64.StdEncoding.EncodeToString(testKeyID)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var envelope map[string]string
+		json.NewDecoder(r.Body).Decode(&envelope)
+		dec, _ := base64.StdEncoding.DecodeString(envelope["request"])
+
+		var req map[string]interface{}
+		json.Unmarshal(dec, &req)
+		if req["first_crypto_period_index"] != float64(7) || req["crypto_period_count"] != float64(2) || req["crypto_period_seconds"] != float64(10) {
+			t.Errorf("unexpected rotation parameters %v", req)
+		}
+
+		resp, _ := json.Marshal(map[string]interface{}{
+			"status": "OK",
+			"tracks": []map[string]interface{}{
+				{"type": "SD", "key_id": kid, "crypto_period_index": 8},
+				{"type": "SD", "key_id": kid, "crypto_period_index": 7},
+				{"type": "HD", "key_id": kid, "crypto_period_index": 7},
+			},
+		})
+		json.NewEncoder(w).Encode(map[string]string{"response": base64.StdEncoding.EncodeToString(resp)})
+	}))
+	defer ts.Close()
+
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: ts.URL})
+	resp, err := wv.RequestRotatingKeys("testing", Policy{Tracks: []string{"SD", "HD"}}, KeyRotation{FirstIndex: 7, Count: 2, PeriodSeconds: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Periods) != 2 || resp.Periods[0].Index != 7 || resp.Periods[1].Index != 8 {
+		t.Fatalf("unexpected periods %+v", resp.Periods)
+	}
+	if len(resp.Periods[0].Tracks) != 2 {
+		t.Error("expected 2 tracks in period 7")
+	}
+
+	p, err := resp.Periods[0].PSSH(PSSHOptions{Provider: "widevine_test", ContentID: []byte("testing")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, _ := p.WidevineHeader()
+	if header.GetCryptoPeriodIndex() != 7 || header.GetCryptoPeriodSeconds() != 10 || len(header.GetKeyId()) != 2 {
+		t.Errorf("unexpected header %v", header)
+	}
+}
+
+func TestRequestRotatingKeysInvalid(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	if _, err := wv.RequestRotatingKeys("testing", Policy{}, KeyRotation{}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,78 @@
+package widevine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProtectionScheme is a Common Encryption scheme identified by its 4CC, as
+// carried in WidevineCencHeader.protection_scheme.
+type ProtectionScheme uint32
+
+// Common Encryption schemes defined by ISO/IEC 23001-7.
+const (
+	// CENC is AES-CTR full sample encryption.
+	CENC ProtectionScheme = 0x63656e63
+	// CBC1 is AES-CBC full sample encryption.
+	CBC1 ProtectionScheme = 0x63626331
+	// CENS is AES-CTR subsample pattern encryption.
+	CENS ProtectionScheme = 0x63656e73
+	// CBCS is AES-CBC subsample pattern encryption, as used by HLS and
+	// FairPlay.
+	CBCS ProtectionScheme = 0x63626373
+)
+
+// ParseProtectionScheme returns the scheme for a 4CC string such as "cbcs".
+func ParseProtectionScheme(s string) (ProtectionScheme, error) {
+	switch strings.ToLower(s) {
+	case "cenc":
+		return CENC, nil
+	case "cbc1":
+		return CBC1, nil
+	case "cens":
+		return CENS, nil
+	case "cbcs":
+		return CBCS, nil
+	}
+	return 0, fmt.Errorf("widevine: unknown protection scheme %q", s)
+}
+
+// String returns the 4CC of the scheme.
+func (s ProtectionScheme) String() string {
+	return fourCC(uint32(s))
+}
+
+// Valid reports whether s is one of the four Common Encryption schemes.
+func (s ProtectionScheme) Valid() bool {
+	switch s {
+	case CENC, CBC1, CENS, CBCS:
+		return true
+	}
+	return false
+}
+
+// ValidateProtectionScheme checks that scheme is supported by every DRM type
+// requested, e.g. FairPlay only supports cbcs and PlayReady only supports
+// cenc and cbcs. A zero scheme leaves the choice to Widevine Cloud.
+func ValidateProtectionScheme(scheme ProtectionScheme, drmTypes []string) error {
+	if scheme == 0 {
+		return nil
+	}
+	if !scheme.Valid() {
+		return fmt.Errorf("widevine: unknown protection scheme 0x%08x", uint32(scheme))
+	}
+
+	for _, drm := range drmTypes {
+		switch strings.ToUpper(drm) {
+		case "FAIRPLAY":
+			if scheme != CBCS {
+				return fmt.Errorf("widevine: FAIRPLAY requires cbcs, got %s", scheme)
+			}
+		case "PLAYREADY":
+			if scheme != CENC && scheme != CBCS {
+				return fmt.Errorf("widevine: PLAYREADY requires cenc or cbcs, got %s", scheme)
+			}
+		}
+	}
+	return nil
+}
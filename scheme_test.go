@@ -0,0 +1,54 @@
+package widevine
+
+import (
+	"testing"
+)
+
+func TestProtectionScheme(t *testing.T) {
+	for _, name := range []string{"cenc", "cbc1", "cens", "cbcs"} {
+		s, err := ParseProtectionScheme(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.Valid() || s.String() != name {
+			t.Errorf("got %s, want %s", s, name)
+		}
+	}
+
+	if CBCS != 0x63626373 {
+		t.Error()
+	}
+	if _, err := ParseProtectionScheme("aes"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestValidateProtectionScheme(t *testing.T) {
+	tests := []struct {
+		scheme ProtectionScheme
+		drm    []string
+		ok     bool
+	}{
+		{0, []string{"FAIRPLAY"}, true},
+		{CBCS, []string{"WIDEVINE", "FAIRPLAY", "PLAYREADY"}, true},
+		{CENC, []string{"WIDEVINE", "FAIRPLAY"}, false},
+		{CBC1, []string{"PLAYREADY"}, false},
+		{CENS, []string{"WIDEVINE"}, true},
+		{ProtectionScheme(1), nil, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateProtectionScheme(tt.scheme, tt.drm)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s %v: got %v", tt.scheme, tt.drm, err)
+		}
+	}
+}
+
+func TestSetPolicyProtectionScheme(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	p := wv.setPolicy("testing", Policy{DRMTypes: []string{"WIDEVINE"}, ProtectionScheme: CBCS})
+	if p["protection_scheme"] != uint32(0x63626373) {
+		t.Error(p["protection_scheme"])
+	}
+}
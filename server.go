@@ -1,3 +1,285 @@
 package widevine
 
-// TODO
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Handler defaults.
+const (
+	defaultMaxBodySize    = 64 << 10
+	defaultHandlerTimeout = 15 * time.Second
+)
+
+// ContentIDResolver returns the content ID a license request is for.
+type ContentIDResolver func(r *http.Request) (string, error)
+
+// QueryContentID returns a resolver reading the content ID from the query
+// parameter name.
+func QueryContentID(name string) ContentIDResolver {
+	return func(r *http.Request) (string, error) {
+		id := r.URL.Query().Get(name)
+		if id == "" {
+			return "", errors.New("widevine: missing content ID parameter " + name)
+		}
+		return id, nil
+	}
+}
+
+// HandlerOptions configures a license proxy Handler.
+//
+// ResolveContentID is required. AllowedOrigins lists the origins allowed to
+// call the handler from a browser; "*" allows any origin and an empty list
+// disables CORS headers. MaxBodySize defaults to 64 KiB and Timeout, which
+// bounds the call to Widevine Cloud, defaults to 15 seconds. Errors are
+// logged to ErrorLog, or the standard logger when nil.
+//
+// Authorizer, when set, decides whether each request may be forwarded to
+// Widevine Cloud and may override LicenseOptions for it.
+//
+// Service certificate requests are answered with ServiceCertificate, a
+// signed service certificate stored locally. When it is nil the certificate
+// is fetched from Widevine Cloud and cached for ServiceCertificateTTL,
+// 24 hours by default.
+type HandlerOptions struct {
+	ResolveContentID      ContentIDResolver
+	Authorizer            Authorizer
+	LicenseOptions        LicenseOptions
+	AllowedOrigins        []string
+	MaxBodySize           int64
+	Timeout               time.Duration
+	ErrorLog              *log.Logger
+	ServiceCertificate    []byte
+	ServiceCertificateTTL time.Duration
+}
+
+// Handler is an http.Handler proxying license challenges from players to
+// Widevine Cloud and writing the license bytes back.
+type Handler struct {
+	wv    *Widevine
+	opts  HandlerOptions
+	certs certificateCache
+}
+
+// NewHandler returns a license proxy Handler using wv for license requests.
+func NewHandler(wv *Widevine, opts HandlerOptions) *Handler {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxBodySize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultHandlerTimeout
+	}
+	if opts.ServiceCertificateTTL <= 0 {
+		opts.ServiceCertificateTTL = defaultServiceCertificateTTL
+	}
+	return &Handler{wv: wv, opts: opts}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.setCORSHeaders(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, OPTIONS")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Read bytes from license request.
+	buf, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, h.opts.MaxBodySize))
+	if err != nil {
+		if errors.As(err, new(*http.MaxBytesError)) {
+			http.Error(w, "license challenge too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "invalid license challenge", http.StatusBadRequest)
+		return
+	}
+	if len(buf) == 0 {
+		http.Error(w, "empty license challenge", http.StatusBadRequest)
+		return
+	}
+	if IsServiceCertificateRequest(buf) {
+		h.serveServiceCertificate(w, r)
+		return
+	}
+
+	if h.opts.ResolveContentID == nil {
+		h.logf("widevine: handler has no content ID resolver")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	contentID, err := h.opts.ResolveContentID(r)
+	if err != nil {
+		h.logf("widevine: resolving content ID failed: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	opts := h.opts.LicenseOptions
+	if h.opts.Authorizer != nil {
+		// Widevine Cloud is the authority on malformed challenges, so a
+		// challenge that cannot be decoded is still forwarded.
+		challenge, _ := ParseLicenseChallenge(buf)
+		auth, err := h.opts.Authorizer.Authorize(r, AuthorizationRequest{
+			ContentID:  contentID,
+			TrackTypes: h.requestedTrackTypes(),
+			Challenge:  challenge,
+		})
+		if err != nil {
+			if errors.Is(err, ErrUnauthorized) {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			h.logf("widevine: authorization for %s failed: %v", contentID, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if !auth.Allowed {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		opts = opts.merge(auth.LicenseOptions)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+	defer cancel()
+
+	body := base64.StdEncoding.EncodeToString(buf)
+	resp, err := h.wv.RequestLicenseWithOptions(ctx, contentID, body, opts)
+	if err != nil {
+		h.logf("widevine: license request for %s failed: %v", contentID, err)
+		code := httpStatusForError(ctx, err)
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+
+	license, err := resp.LicenseBytes()
+	if err != nil {
+		h.logf("widevine: invalid license for %s: %v", contentID, err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	// Write decoded license bytes back to player.
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(license)
+}
+
+// serveServiceCertificate answers a service certificate request. The
+// certificate is public, so the request is not authorized.
+func (h *Handler) serveServiceCertificate(w http.ResponseWriter, r *http.Request) {
+	var msg []byte
+	if h.opts.ServiceCertificate != nil {
+		var err error
+		if msg, err = ServiceCertificateMessage(h.opts.ServiceCertificate); err != nil {
+			h.logf("widevine: invalid service certificate: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+		defer cancel()
+		var err error
+		if msg, err = h.certs.get(ctx, h.opts.ServiceCertificateTTL, h.opts.Timeout, h.wv.RequestServiceCertificate); err != nil {
+			h.logf("widevine: service certificate request failed: %v", err)
+			code := httpStatusForError(ctx, err)
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(msg)
+}
+
+// requestedTrackTypes returns the track types the license would grant with
+// the handler's license options.
+func (h *Handler) requestedTrackTypes() []TrackType {
+	if specs := h.opts.LicenseOptions.ContentKeySpecs; len(specs) > 0 {
+		types := make([]TrackType, 0, len(specs))
+		for _, spec := range specs {
+			types = append(types, spec.TrackType)
+		}
+		return types
+	}
+
+	allowed := h.opts.LicenseOptions.AllowedTrackTypes
+	if allowed == "" {
+		allowed = h.wv.AllowedTrackTypes
+	}
+	if allowed == "" {
+		allowed = defaultAllowedTrackTypes
+	}
+	return allowed.TrackTypes()
+}
+
+func (h *Handler) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	for _, allowed := range h.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Add("Vary", "Origin")
+			return
+		}
+	}
+}
+
+func (h *Handler) logf(format string, v ...interface{}) {
+	if h.opts.ErrorLog != nil {
+		h.opts.ErrorLog.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// statusCodes maps Widevine status values to the HTTP status returned to
+// the player.
+var statusCodes = map[Status]int{
+	StatusInvalidLicenseChallenge: http.StatusBadRequest,
+	StatusMalformedRequest:        http.StatusBadRequest,
+	StatusInvalidRequest:          http.StatusBadRequest,
+	StatusInvalidContentInfo:      http.StatusBadRequest,
+	StatusAccessDenied:            http.StatusForbidden,
+	StatusPolicyUnknown:           http.StatusInternalServerError,
+	StatusSignatureFailed:         http.StatusInternalServerError,
+	StatusProviderMissing:         http.StatusInternalServerError,
+	StatusSigningKeyExpired:       http.StatusInternalServerError,
+	StatusInternalError:           http.StatusBadGateway,
+}
+
+func httpStatusForError(ctx context.Context, err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		if code, ok := statusCodes[statusErr.Status]; ok {
+			return code
+		}
+		return http.StatusBadGateway
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	var transportErr *TransportError
+	var httpErr *HTTPStatusError
+	var decodeErr *DecodeError
+	if errors.As(err, &transportErr) || errors.As(err, &httpErr) || errors.As(err, &decodeErr) {
+		return http.StatusBadGateway
+	}
+
+	// Errors returned before the request was sent, such as invalid options.
+	return http.StatusInternalServerError
+}
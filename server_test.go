@@ -0,0 +1,126 @@
+package widevine
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// licenseServer mocks /cenc/getlicense, answering with status and the
+// license "license-bytes".
+func licenseServer(t *testing.T, status string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var envelope map[string]string
+		json.NewDecoder(r.Body).Decode(&envelope)
+		dec, _ := base64.StdEncoding.DecodeString(envelope["request"])
+
+		var req map[string]string
+		json.Unmarshal(dec, &req)
+		if req["content_id"] != base64.StdEncoding.EncodeToString([]byte("testing")) {
+			t.Errorf("unexpected content ID %s", req["content_id"])
+		}
+
+		license := base64.StdEncoding.EncodeToString([]byte("license-bytes"))
+		fmt.Fprintf(w, `{"status": %q, "license": %q}`, status, license)
+	}))
+}
+
+func testHandler(t *testing.T, url string) *Handler {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test", URL: url})
+	return NewHandler(wv, HandlerOptions{
+		ResolveContentID: QueryContentID("content_id"),
+		AllowedOrigins:   []string{"https://player.example.com"},
+		MaxBodySize:      1024,
+		ErrorLog:         log.New(ioutil.Discard, "", 0),
+	})
+}
+
+func TestHandler(t *testing.T) {
+	ts := licenseServer(t, "OK")
+	defer ts.Close()
+
+	r := httptest.NewRequest("POST", "/proxy?content_id=testing", strings.NewReader("challenge"))
+	r.Header.Set("Origin", "https://player.example.com")
+	w := httptest.NewRecorder()
+	testHandler(t, ts.URL).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || w.Body.String() != "license-bytes" {
+		t.Errorf("got %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://player.example.com" {
+		t.Error("expected CORS header")
+	}
+}
+
+func TestHandlerCORS(t *testing.T) {
+	r := httptest.NewRequest("OPTIONS", "/proxy", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	testHandler(t, "http://127.0.0.1:0").ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("unexpected response %d %v", w.Code, w.Header())
+	}
+}
+
+func TestHandlerBadRequest(t *testing.T) {
+	h := testHandler(t, "http://127.0.0.1:0")
+
+	// A failed read, such as a client disconnect, is not a body size error.
+	r := httptest.NewRequest("POST", "/proxy?content_id=testing", iotest.ErrReader(errors.New("connection reset")))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("read error: got %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	// Resolver errors are logged, not sent to the client.
+	h.opts.ResolveContentID = func(r *http.Request) (string, error) {
+		return "", errors.New("catalog db timeout")
+	}
+	r = httptest.NewRequest("POST", "/proxy", strings.NewReader("challenge"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || strings.Contains(w.Body.String(), "catalog") {
+		t.Errorf("content ID error: got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	denied := licenseServer(t, "ACCESS_DENIED")
+	defer denied.Close()
+
+	tests := []struct {
+		name   string
+		url    string
+		method string
+		target string
+		body   []byte
+		code   int
+	}{
+		{"method", denied.URL, "GET", "/proxy?content_id=testing", nil, http.StatusMethodNotAllowed},
+		{"empty", denied.URL, "POST", "/proxy?content_id=testing", nil, http.StatusBadRequest},
+		{"too large", denied.URL, "POST", "/proxy?content_id=testing", bytes.Repeat([]byte{1}, 2048), http.StatusRequestEntityTooLarge},
+		{"content id", denied.URL, "POST", "/proxy", []byte("challenge"), http.StatusBadRequest},
+		{"denied", denied.URL, "POST", "/proxy?content_id=testing", []byte("challenge"), http.StatusForbidden},
+		{"unreachable", "http://127.0.0.1:1", "POST", "/proxy?content_id=testing", []byte("challenge"), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.target, bytes.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		testHandler(t, tt.url).ServeHTTP(w, r)
+		if w.Code != tt.code {
+			t.Errorf("%s: got %d, want %d", tt.name, w.Code, tt.code)
+		}
+	}
+}
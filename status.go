@@ -0,0 +1,89 @@
+package widevine
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Status is the status of a Widevine Cloud response.
+type Status string
+
+// Documented Widevine Cloud statuses.
+const (
+	StatusOK                      Status = "OK"
+	StatusSignatureFailed         Status = "SIGNATURE_FAILED"
+	StatusInvalidLicenseChallenge Status = "INVALID_LICENSE_CHALLENGE"
+	StatusInvalidContentInfo      Status = "INVALID_CONTENT_INFO"
+	StatusPolicyUnknown           Status = "POLICY_UNKNOWN"
+	StatusMalformedRequest        Status = "MALFORMED_REQUEST"
+	StatusInternalError           Status = "INTERNAL_ERROR"
+	StatusProviderMissing         Status = "PROVIDER_MISSING"
+	StatusInvalidRequest          Status = "INVALID_REQUEST"
+	StatusAccessDenied            Status = "ACCESS_DENIED"
+	StatusSigningKeyExpired       Status = "SIGNING_KEY_EXPIRED"
+)
+
+// Known reports whether s is one of the documented statuses.
+func (s Status) Known() bool {
+	switch s {
+	case StatusOK, StatusSignatureFailed, StatusInvalidLicenseChallenge,
+		StatusInvalidContentInfo, StatusPolicyUnknown, StatusMalformedRequest,
+		StatusInternalError, StatusProviderMissing, StatusInvalidRequest,
+		StatusAccessDenied, StatusSigningKeyExpired:
+		return true
+	}
+	return false
+}
+
+// Retryable reports whether a request failing with s may succeed when sent
+// again unchanged. Only INTERNAL_ERROR is transient; every other status
+// requires a change to the request, the credentials or the account.
+//
+// With a RetryPolicy, content key requests are retried on retryable
+// statuses. License requests are not idempotent and are never retried on a
+// status; callers decide whether to send them again.
+func (s Status) Retryable() bool {
+	return s == StatusInternalError
+}
+
+// Err returns nil for OK and a *StatusError otherwise.
+func (s Status) Err() error {
+	if s == StatusOK {
+		return nil
+	}
+	return &StatusError{Status: s}
+}
+
+// Err returns nil when the response status is OK and a *StatusError
+// otherwise.
+func (r GetContentKeyResponse) Err() error {
+	return r.Status.Err()
+}
+
+// Err returns nil when the response status is OK and a *StatusError
+// carrying the internal status otherwise.
+func (r GetLicenseResponse) Err() error {
+	if r.Status == StatusOK {
+		return nil
+	}
+	return &StatusError{Status: r.Status, InternalStatus: r.InternalStatus}
+}
+
+// IsRetryable reports whether err, returned by a Widevine Cloud call, is
+// transient: a transport error, a 429 or 5xx HTTP status, or a retryable
+// Widevine status. Errors caused by the request itself are permanent.
+//
+// IsRetryable is advisory: it does not mean the RetryPolicy of the client
+// retried the call, which depends on the call being idempotent.
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status.Retryable()
+	}
+	var httpErr *HTTPStatusError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
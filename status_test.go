@@ -0,0 +1,74 @@
+package widevine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStatusErr(t *testing.T) {
+	if err := StatusOK.Err(); err != nil {
+		t.Errorf("expected nil error for OK, got %v", err)
+	}
+
+	var statusErr *StatusError
+	err := StatusPolicyUnknown.Err()
+	if !errors.As(err, &statusErr) || statusErr.Status != StatusPolicyUnknown {
+		t.Fatalf("expected *StatusError, got %v", err)
+	}
+	if statusErr.Retryable() {
+		t.Error("POLICY_UNKNOWN must not be retryable")
+	}
+}
+
+func TestResponseErr(t *testing.T) {
+	var resp GetLicenseResponse
+	if err := json.Unmarshal([]byte(`{"status": "INTERNAL_ERROR", "internal_status": 42}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusInternalError {
+		t.Errorf("got status %s", resp.Status)
+	}
+
+	var statusErr *StatusError
+	if err := resp.Err(); !errors.As(err, &statusErr) || statusErr.InternalStatus != 42 {
+		t.Errorf("got %v", err)
+	}
+	if err := (GetContentKeyResponse{Status: StatusOK}).Err(); err != nil {
+		t.Errorf("expected nil error for OK, got %v", err)
+	}
+}
+
+func TestStatusKnown(t *testing.T) {
+	if !StatusSigningKeyExpired.Known() {
+		t.Error("expected SIGNING_KEY_EXPIRED to be known")
+	}
+	if Status("SOMETHING_NEW").Known() {
+		t.Error("expected SOMETHING_NEW to be unknown")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{StatusInternalError.Err(), true},
+		{StatusAccessDenied.Err(), false},
+		{StatusSignatureFailed.Err(), false},
+		{fmt.Errorf("wrapped: %w", StatusInternalError.Err()), true},
+		{&HTTPStatusError{StatusCode: http.StatusServiceUnavailable}, true},
+		{&HTTPStatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{&HTTPStatusError{StatusCode: http.StatusBadRequest}, false},
+		{&TransportError{Err: errors.New("connection reset")}, true},
+		{&DecodeError{Err: errors.New("bad json")}, false},
+		{errors.New("invalid options"), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
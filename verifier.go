@@ -0,0 +1,152 @@
+package widevine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Errors returned by Verifier.
+var (
+	ErrUnknownSigner    = errors.New("widevine: unknown signer")
+	ErrInvalidSignature = errors.New("widevine: invalid signature")
+	ErrProviderMismatch = errors.New("widevine: provider does not match signer")
+)
+
+// Envelope is a signed request as sent to Widevine Cloud. Request is the
+// base64 encoded request JSON.
+type Envelope struct {
+	Request   string `json:"request"`
+	Signature string `json:"signature"`
+	Signer    string `json:"signer"`
+}
+
+// ProviderKey is the AES key and IV a provider signs requests with.
+type ProviderKey struct {
+	Key []byte
+	IV  []byte
+}
+
+// KeyRegistry returns the keys accepted for the signatures of a provider.
+// A provider may have several keys while its key is being rotated.
+type KeyRegistry interface {
+	ProviderKeys(provider string) []ProviderKey
+}
+
+// StaticKeys is a KeyRegistry of fixed keys by provider.
+type StaticKeys map[string][]ProviderKey
+
+// ProviderKeys returns the keys of provider.
+func (k StaticKeys) ProviderKeys(provider string) []ProviderKey {
+	return k[provider]
+}
+
+// SignedRequest is a verified request. Base64 fields of the request JSON
+// are decoded. Content key requests set Tracks, DRMTypes and the crypto
+// period fields; license requests set Payload, AllowedTrackTypes and
+// ContentKeySpecs. JSON holds the complete request.
+type SignedRequest struct {
+	Signer string `json:"-"`
+
+	ContentID []byte `json:"content_id"`
+	Provider  string `json:"provider"`
+	Policy    string `json:"policy"`
+
+	Tracks                 []RequestTrack `json:"tracks"`
+	DRMTypes               []string       `json:"drm_types"`
+	ProtectionScheme       uint32         `json:"protection_scheme"`
+	FirstCryptoPeriodIndex *uint32        `json:"first_crypto_period_index"`
+	CryptoPeriodCount      int            `json:"crypto_period_count"`
+	CryptoPeriodSeconds    uint32         `json:"crypto_period_seconds"`
+
+	Payload           []byte                 `json:"payload"`
+	AllowedTrackTypes AllowedTrackTypes      `json:"allowed_track_types"`
+	PolicyOverrides   map[string]interface{} `json:"policy_overrides"`
+	ContentKeySpecs   []RequestKeySpec       `json:"content_key_specs"`
+
+	JSON []byte `json:"-"`
+}
+
+// RequestTrack is a track of a content key request. KeyID and Key are only
+// set for external keys.
+type RequestTrack struct {
+	Type  string `json:"type"`
+	KeyID []byte `json:"key_id"`
+	Key   []byte `json:"key"`
+}
+
+// RequestKeySpec is a content key spec of a license request.
+type RequestKeySpec struct {
+	TrackType     TrackType     `json:"track_type"`
+	KeyID         []byte        `json:"key_id"`
+	Key           []byte        `json:"key"`
+	SecurityLevel SecurityLevel `json:"security_level"`
+}
+
+// Verifier verifies the signature of request envelopes, e.g. in a gateway
+// forwarding requests to Widevine Cloud.
+type Verifier struct {
+	Keys KeyRegistry
+}
+
+// NewVerifier returns a Verifier accepting the keys of keys.
+func NewVerifier(keys KeyRegistry) *Verifier {
+	return &Verifier{Keys: keys}
+}
+
+// Verify decodes the JSON envelope b and verifies it with VerifyEnvelope.
+func (v *Verifier) Verify(b []byte) (*SignedRequest, error) {
+	var e Envelope
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("widevine: malformed envelope: %v", err)
+	}
+	return v.VerifyEnvelope(e)
+}
+
+// VerifyEnvelope verifies the signature of e against the keys of its
+// signer and returns the decoded request. It returns an error wrapping
+// ErrUnknownSigner when the signer has no keys, ErrInvalidSignature when no
+// valid key matches and ErrProviderMismatch when the request names another
+// provider than the signer. Invalid keys of the registry are skipped.
+func (v *Verifier) VerifyEnvelope(e Envelope) (*SignedRequest, error) {
+	msg, err := base64.StdEncoding.DecodeString(e.Request)
+	if err != nil {
+		return nil, fmt.Errorf("widevine: malformed request: %v", err)
+	}
+
+	keys := v.Keys.ProviderKeys(e.Signer)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSigner, e.Signer)
+	}
+	verified, valid := false, 0
+	var keyErr error
+	for _, k := range keys {
+		crypto, err := NewCrypto(k.Key, k.IV)
+		if err != nil {
+			keyErr = err
+			continue
+		}
+		valid++
+		if crypto.verifySignature(msg, e.Signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		if valid == 0 {
+			return nil, fmt.Errorf("%w from %q: no valid key: %v", ErrInvalidSignature, e.Signer, keyErr)
+		}
+		return nil, fmt.Errorf("%w from %q", ErrInvalidSignature, e.Signer)
+	}
+
+	req := &SignedRequest{Signer: e.Signer, JSON: msg}
+	if err := json.Unmarshal(msg, req); err != nil {
+		return nil, fmt.Errorf("widevine: malformed request: %v", err)
+	}
+	// Content key requests name the provider in the URL only.
+	if req.Provider != "" && req.Provider != e.Signer {
+		return nil, fmt.Errorf("%w: request for %q signed by %q", ErrProviderMismatch, req.Provider, e.Signer)
+	}
+	return req, nil
+}
@@ -0,0 +1,129 @@
+package widevine
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func testEnvelope(t *testing.T, body interface{}) []byte {
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVerifierLicenseRequest(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	msg, err := wv.buildLicenseMessage(context.Background(), "testing", "CAQ=", LicenseOptions{
+		AllowedTrackTypes: SDHD,
+		ContentKeySpecs:   []ContentKeySpec{{TrackType: TrackSD, KeyID: testKeyID}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := ProviderKey{Key: make([]byte, 32), IV: make([]byte, 16)}
+	v := NewVerifier(StaticKeys{"widevine_test": {other, {Key: key, IV: iv}}})
+	req, err := v.Verify(testEnvelope(t, msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Signer != "widevine_test" || string(req.ContentID) != "testing" || req.AllowedTrackTypes != SDHD {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if !bytes.Equal(req.Payload, []byte{0x08, 0x04}) {
+		t.Errorf("got payload %x", req.Payload)
+	}
+	if len(req.ContentKeySpecs) != 1 || !bytes.Equal(req.ContentKeySpecs[0].KeyID, testKeyID) {
+		t.Errorf("got content key specs %+v", req.ContentKeySpecs)
+	}
+}
+
+func TestVerifierContentKeyRequest(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	p := wv.setPolicy("testing", Policy{Tracks: []string{"SD", "HD"}, DRMTypes: []string{"WIDEVINE"}})
+	msg, err := wv.buildCKMessage(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := NewVerifier(StaticKeys{"widevine_test": {{Key: key, IV: iv}}}).Verify(testEnvelope(t, msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Tracks) != 2 || req.Tracks[1].Type != "HD" || req.DRMTypes[0] != "WIDEVINE" {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
+
+func TestVerifierErrors(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	msg, err := wv.buildCKMessage(context.Background(), map[string]interface{}{"content_id": "dGVzdGluZw=="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewVerifier(StaticKeys{"widevine_test": {{Key: key, IV: iv}}})
+
+	tampered := Envelope{
+		Request:   base64.StdEncoding.EncodeToString([]byte(`{"content_id":"b3RoZXI="}`)),
+		Signature: msg.Signature,
+		Signer:    "widevine_test",
+	}
+	if _, err := v.VerifyEnvelope(tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	unknown := Envelope{Request: msg.Request, Signature: msg.Signature, Signer: "other"}
+	if _, err := v.VerifyEnvelope(unknown); !errors.Is(err, ErrUnknownSigner) {
+		t.Errorf("expected ErrUnknownSigner, got %v", err)
+	}
+
+	garbled := Envelope{Request: msg.Request, Signature: "not base64!", Signer: "widevine_test"}
+	if _, err := v.VerifyEnvelope(garbled); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	if _, err := v.Verify([]byte("{")); err == nil {
+		t.Error("expected error for malformed envelope")
+	}
+}
+
+func TestVerifierInvalidRegistryKey(t *testing.T) {
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "widevine_test"})
+	msg, err := wv.buildCKMessage(context.Background(), map[string]interface{}{"content_id": "dGVzdGluZw=="})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A malformed key in the rotation window does not block the others.
+	bad := ProviderKey{Key: key[:16], IV: iv}
+	v := NewVerifier(StaticKeys{"widevine_test": {bad, {Key: key, IV: iv}}})
+	if _, err := v.VerifyEnvelope(msg.Envelope); err != nil {
+		t.Errorf("expected verified request, got %v", err)
+	}
+
+	v = NewVerifier(StaticKeys{"widevine_test": {bad}})
+	if _, err := v.VerifyEnvelope(msg.Envelope); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerifierProviderMismatch(t *testing.T) {
+	// Signed with the key of widevine_test, naming another provider.
+	wv := mustNew(t, Options{Key: key, IV: iv, Provider: "other"})
+	msg, err := wv.buildLicenseMessage(context.Background(), "testing", "CAQ=", LicenseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Signer = "widevine_test"
+
+	v := NewVerifier(StaticKeys{"widevine_test": {{Key: key, IV: iv}}})
+	if _, err := v.VerifyEnvelope(msg.Envelope); !errors.Is(err, ErrProviderMismatch) {
+		t.Errorf("expected ErrProviderMismatch, got %v", err)
+	}
+}
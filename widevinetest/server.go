@@ -0,0 +1,344 @@
+// Package widevinetest provides an in-process fake of Widevine Cloud for
+// tests.
+//
+// The fake serves /cenc/getcontentkey/<provider> and /cenc/getlicense,
+// verifies request signatures with the provider key and IV, and answers with
+// deterministic keys, PSSH data and licenses. Responses can be scripted to
+// return error statuses, HTTP errors, latency or malformed bodies.
+package widevinetest
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/alfg/widevine/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// Widevine system ID as returned in content key responses.
+const widevineSystemID = "edef8ba9-79d6-4ace-a3c8-27dcd51d21ed"
+
+// ServiceCertificate is the certificate returned for service certificate
+// requests, wrapped in a SignedMessage of type SERVICE_CERTIFICATE.
+var ServiceCertificate = []byte("widevinetest-service-certificate")
+
+// Response scripts the answer to one request.
+//
+// Status defaults to "OK". A non-zero HTTPStatus answers with that HTTP
+// status and no Widevine response. Body, when set, is written as is instead
+// of the generated response. Latency delays the answer.
+type Response struct {
+	Status         string
+	InternalStatus int
+	HTTPStatus     int
+	Body           []byte
+	Latency        time.Duration
+}
+
+// Request is a request received by the Server.
+type Request struct {
+	Path    string
+	Signer  string
+	Message map[string]interface{}
+}
+
+// Server is a fake Widevine Cloud. Set the URL of Options to Server.URL.
+type Server struct {
+	*httptest.Server
+
+	provider string
+	key      []byte
+	iv       []byte
+
+	mu       sync.Mutex
+	script   []Response
+	requests []Request
+}
+
+// NewServer starts a fake Widevine Cloud accepting requests signed by
+// provider with key and iv. The caller should call Close when finished.
+func NewServer(provider string, key, iv []byte) *Server {
+	s := &Server{provider: provider, key: key, iv: iv}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cenc/getcontentkey/"+provider, s.serveContentKey)
+	mux.HandleFunc("/cenc/getlicense", s.serveLicense)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Enqueue scripts the answers to the next requests, in order. Requests
+// received when no scripted response is left are answered normally.
+func (s *Server) Enqueue(resp ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.script = append(s.script, resp...)
+}
+
+// Requests returns the requests received so far, including those with an
+// invalid signature.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// next returns the scripted response for the request.
+func (s *Server) next(req Request) Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	if len(s.script) == 0 {
+		return Response{}
+	}
+	resp := s.script[0]
+	s.script = s.script[1:]
+	return resp
+}
+
+// Signature returns the signature of message for key and iv: the SHA-1
+// hash of message, encrypted with AES-CBC and base64 encoded.
+func Signature(message, key, iv []byte) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(iv) != aes.BlockSize {
+		return "", fmt.Errorf("widevinetest: IV must be %d bytes", aes.BlockSize)
+	}
+	h := sha1.Sum(message)
+	padding := aes.BlockSize - len(h)%aes.BlockSize
+	plaintext := append(h[:], bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	out := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, plaintext)
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// readRequest decodes and verifies the envelope of r. It returns the
+// decoded request and the Widevine status to answer with.
+func (s *Server) readRequest(r *http.Request) (Request, string) {
+	req := Request{Path: r.URL.Path}
+	var envelope struct {
+		Request   string `json:"request"`
+		Signature string `json:"signature"`
+		Signer    string `json:"signer"`
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || json.Unmarshal(body, &envelope) != nil {
+		return req, "MALFORMED_REQUEST"
+	}
+	req.Signer = envelope.Signer
+
+	msg, err := base64.StdEncoding.DecodeString(envelope.Request)
+	if err != nil || json.Unmarshal(msg, &req.Message) != nil {
+		return req, "MALFORMED_REQUEST"
+	}
+	if envelope.Signer != s.provider {
+		return req, "PROVIDER_MISSING"
+	}
+	sig, err := Signature(msg, s.key, s.iv)
+	if err != nil || sig != envelope.Signature {
+		return req, "SIGNATURE_FAILED"
+	}
+	return req, "OK"
+}
+
+// reply writes the generated response v, or the scripted answer.
+func reply(w http.ResponseWriter, r *http.Request, resp Response, v interface{}) {
+	if resp.Latency > 0 {
+		select {
+		case <-time.After(resp.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if resp.HTTPStatus != 0 {
+		http.Error(w, http.StatusText(resp.HTTPStatus), resp.HTTPStatus)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Body != nil {
+		w.Write(resp.Body)
+		return
+	}
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) serveContentKey(w http.ResponseWriter, r *http.Request) {
+	req, status := s.readRequest(r)
+	resp := s.next(req)
+	if resp.Status != "" {
+		status = resp.Status
+	}
+
+	out := map[string]interface{}{"status": status}
+	if status == "OK" {
+		out["drm"] = []map[string]string{{"type": "WIDEVINE", "system_id": widevineSystemID}}
+		out["tracks"] = s.tracks(req.Message)
+		out["already_used"] = false
+	}
+	b, _ := json.Marshal(out)
+	reply(w, r, resp, map[string]string{"response": base64.StdEncoding.EncodeToString(b)})
+}
+
+// tracks returns the tracks of a content key response. External keys are
+// echoed; other keys are derived from the provider, content ID, track type
+// and crypto period index.
+func (s *Server) tracks(msg map[string]interface{}) []map[string]interface{} {
+	contentID, _ := msg["content_id"].(string)
+	cid, _ := base64.StdEncoding.DecodeString(contentID)
+
+	var periods []uint32
+	if count, ok := msg["crypto_period_count"].(float64); ok {
+		first, _ := msg["first_crypto_period_index"].(float64)
+		for i := 0; i < int(count); i++ {
+			periods = append(periods, uint32(first)+uint32(i))
+		}
+	}
+
+	var out []map[string]interface{}
+	requested, _ := msg["tracks"].([]interface{})
+	for _, t := range requested {
+		track, _ := t.(map[string]interface{})
+		trackType, _ := track["type"].(string)
+		if kid, ok := track["key_id"].(string); ok {
+			keyID, _ := base64.StdEncoding.DecodeString(kid)
+			out = append(out, s.track(trackType, cid, keyID, track["key"], nil))
+			continue
+		}
+		if periods == nil {
+			keyID, key := KeyFor(s.provider, cid, trackType, 0)
+			out = append(out, s.track(trackType, cid, keyID, base64.StdEncoding.EncodeToString(key), nil))
+			continue
+		}
+		for _, period := range periods {
+			period := period
+			keyID, key := KeyFor(s.provider, cid, trackType, period)
+			out = append(out, s.track(trackType, cid, keyID, base64.StdEncoding.EncodeToString(key), &period))
+		}
+	}
+	return out
+}
+
+func (s *Server) track(trackType string, contentID, keyID []byte, key interface{}, period *uint32) map[string]interface{} {
+	header, _ := protobuf.Marshal(&proto.WidevineCencHeader{
+		KeyId:             [][]byte{keyID},
+		Provider:          protobuf.String(s.provider),
+		ContentId:         contentID,
+		CryptoPeriodIndex: period,
+	})
+	track := map[string]interface{}{
+		"type":   trackType,
+		"key_id": base64.StdEncoding.EncodeToString(keyID),
+		"key":    key,
+		"pssh": []map[string]string{{
+			"drm_type": "WIDEVINE",
+			"data":     base64.StdEncoding.EncodeToString(header),
+		}},
+	}
+	if period != nil {
+		track["crypto_period_index"] = *period
+	}
+	return track
+}
+
+// KeyFor returns the key ID and key the Server generates for a track.
+// period is 0 without key rotation.
+func KeyFor(provider string, contentID []byte, trackType string, period uint32) (keyID, key []byte) {
+	seed := fmt.Sprintf("%s\n%x\n%s\n%d", provider, contentID, trackType, period)
+	kid := sha256.Sum256([]byte("key_id\n" + seed))
+	k := sha256.Sum256([]byte("key\n" + seed))
+	return kid[:16], k[:16]
+}
+
+func (s *Server) serveLicense(w http.ResponseWriter, r *http.Request) {
+	req, status := s.readRequest(r)
+	resp := s.next(req)
+	if resp.Status != "" {
+		status = resp.Status
+	}
+
+	out := map[string]interface{}{"status": status}
+	if resp.InternalStatus != 0 {
+		out["internal_status"] = resp.InternalStatus
+	}
+	if status == "OK" {
+		payload, _ := req.Message["payload"].(string)
+		challenge, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil || len(challenge) == 0 {
+			out["status"] = "INVALID_LICENSE_CHALLENGE"
+			reply(w, r, resp, out)
+			return
+		}
+		if bytes.Equal(challenge, []byte{0x08, 0x04}) {
+			cert, _ := protobuf.Marshal(&proto.SignedMessage{
+				Type: proto.SignedMessage_SERVICE_CERTIFICATE.Enum(),
+				Msg:  ServiceCertificate,
+			})
+			out["license"] = base64.StdEncoding.EncodeToString(cert)
+			out["message_type"] = "SERVICE_CERTIFICATE"
+			reply(w, r, resp, out)
+			return
+		}
+
+		contentID, _ := req.Message["content_id"].(string)
+		cid, _ := base64.StdEncoding.DecodeString(contentID)
+		out["license"] = base64.StdEncoding.EncodeToString(License(cid, challenge))
+		out["message_type"] = "LICENSE"
+		out["content_provider"] = s.provider
+		out["license_metadata"] = map[string]string{
+			"content_id":   contentID,
+			"license_type": "STREAMING",
+			"request_type": "NEW",
+		}
+		if allowed, ok := req.Message["allowed_track_types"].(string); ok {
+			out["supported_tracks"] = supportedTracks(s.provider, cid, allowed)
+		}
+	}
+	reply(w, r, resp, out)
+}
+
+// License returns the license the Server issues for a challenge: a
+// SignedMessage of type LICENSE carrying the SHA-256 hash of the content ID
+// and challenge.
+func License(contentID, challenge []byte) []byte {
+	h := sha256.Sum256(append(append([]byte(nil), contentID...), challenge...))
+	b, _ := protobuf.Marshal(&proto.SignedMessage{
+		Type: proto.SignedMessage_LICENSE.Enum(),
+		Msg:  h[:],
+	})
+	return b
+}
+
+func supportedTracks(provider string, contentID []byte, allowed string) []map[string]string {
+	types := []string{"AUDIO", "SD"}
+	switch allowed {
+	case "SD_HD":
+		types = append(types, "HD")
+	case "SD_UHD1":
+		types = append(types, "HD", "UHD1")
+	case "SD_UHD2":
+		types = append(types, "HD", "UHD1", "UHD2")
+	}
+
+	var out []map[string]string
+	for _, t := range types {
+		keyID, _ := KeyFor(provider, contentID, t, 0)
+		out = append(out, map[string]string{
+			"type":   t,
+			"key_id": base64.StdEncoding.EncodeToString(keyID),
+		})
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package widevinetest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfg/widevine"
+	"github.com/alfg/widevine/widevinetest"
+)
+
+var (
+	key = []byte{
+		0x1a, 0xe8, 0xcc, 0xd0, 0xe7, 0x98, 0x5c, 0xc0,
+		0xb6, 0x20, 0x3a, 0x55, 0x85, 0x5a, 0x10, 0x34,
+		0xaf, 0xc2, 0x52, 0x98, 0x0e, 0x97, 0x0c, 0xa9,
+		0x0e, 0x52, 0x02, 0x68, 0x9f, 0x94, 0x7a, 0xb9}
+
+	iv = []byte{
+		0xd5, 0x8c, 0xe9, 0x54, 0x20, 0x3b, 0x7c, 0x9a,
+		0x9a, 0x9d, 0x46, 0x7f, 0x59, 0x83, 0x92, 0x49}
+)
+
+var policy = widevine.Policy{
+	Tracks:   []string{"SD", "HD"},
+	DRMTypes: []string{"WIDEVINE"},
+	Policy:   "default",
+}
+
+func newClient(t *testing.T, url string, k []byte) *widevine.Widevine {
+	wv, err := widevine.New(widevine.Options{
+		Key:         k,
+		IV:          iv,
+		Provider:    "widevine_test",
+		Environment: widevine.Custom,
+		URL:         url,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wv
+}
+
+func TestContentKey(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+
+	resp, err := newClient(t, ts.URL, key).RequestContentKey("testing", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	track, ok := resp.Track("HD")
+	if !ok {
+		t.Fatal("expected HD track")
+	}
+
+	wantKeyID, wantKey := widevinetest.KeyFor("widevine_test", []byte("testing"), "HD", 0)
+	keyID, _ := track.KeyIDBytes()
+	k, _ := track.KeyBytes()
+	if !bytes.Equal(keyID, wantKeyID) || !bytes.Equal(k, wantKey) {
+		t.Errorf("got key ID %x key %x, want %x %x", keyID, k, wantKeyID, wantKey)
+	}
+	if _, err := track.WidevinePSSH(); err != nil {
+		t.Error(err)
+	}
+
+	reqs := ts.Requests()
+	if len(reqs) != 1 || reqs[0].Signer != "widevine_test" || reqs[0].Path != "/cenc/getcontentkey/widevine_test" {
+		t.Errorf("unexpected requests %+v", reqs)
+	}
+}
+
+func TestSignatureFailed(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+
+	wrongKey := append([]byte(nil), key...)
+	wrongKey[0] ^= 0xff
+	_, err := newClient(t, ts.URL, wrongKey).RequestContentKey("testing", policy)
+
+	var statusErr *widevine.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != widevine.StatusSignatureFailed {
+		t.Errorf("expected SIGNATURE_FAILED, got %v", err)
+	}
+}
+
+func TestScriptedResponses(t *testing.T) {
+	ts := widevinetest.NewServer("widevine_test", key, iv)
+	defer ts.Close()
+	wv := newClient(t, ts.URL, key)
+
+	ts.Enqueue(
+		widevinetest.Response{Status: "INTERNAL_ERROR", InternalStatus: 7},
+		widevinetest.Response{HTTPStatus: http.StatusBadRequest},
+		widevinetest.Response{Body: []byte("{not json")},
+		widevinetest.Response{Latency: time.Second},
+	)
+
+	var statusErr *widevine.StatusError
+	if _, err := wv.RequestLicense("testing", "CAQ="); !errors.As(err, &statusErr) || statusErr.InternalStatus != 7 {
+		t.Errorf("expected INTERNAL_ERROR, got %v", err)
+	}
+	var httpErr *widevine.HTTPStatusError
+	if _, err := wv.RequestLicense("testing", "CAQ="); !errors.As(err, &httpErr) {
+		t.Errorf("expected *HTTPStatusError, got %v", err)
+	}
+	var decodeErr *widevine.DecodeError
+	if _, err := wv.RequestLicense("testing", "CAQ="); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := wv.RequestLicenseContext(ctx, "testing", "CAQ="); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// The script is exhausted.
+	if _, err := wv.RequestLicense("testing", "CAQ="); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}